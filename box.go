@@ -0,0 +1,89 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "strings"
+
+// type Border selects characters Box frames are drawn with.
+type Border int
+
+// Box borders
+const (
+	Single  Border = iota // ┌─┐
+	Double                // ╔═╗
+	Rounded               // ╭─╮
+	ASCII                 // +-+
+)
+
+// corners: top-left, top-right, bottom-left, bottom-right; then - and |
+var borders = [...][6]string{
+	Single:  {"┌", "┐", "└", "┘", "─", "│"},
+	Double:  {"╔", "╗", "╚", "╝", "═", "║"},
+	Rounded: {"╭", "╮", "╰", "╯", "─", "│"},
+	ASCII:   {"+", "+", "+", "+", "-", "|"},
+}
+
+// Method Box writes body in a frame, with title (if given) set in the top
+// border. Box width is computed from display width of the body lines.
+// Optional parameters, in any order, are: a Border, and an int padding -
+// number of spaces between frame and text (def: 1). Eg. usage:
+// `pb.Box("Note", "Remember to...", cout.Double, 2)`
+func (b *Bld) Box(title, body string, a ...interface{}) {
+	bc, pad := borders[Single], 1
+	for _, aa := range a {
+		switch v := aa.(type) {
+		case Border:
+			if v >= 0 && int(v) < len(borders) {
+				bc = borders[v]
+			}
+		case int:
+			if v >= 0 {
+				pad = v
+			}
+		}
+	}
+	if b.Mode == JSONL {
+		b.record("info", body, []interface{}{"title", title})
+		return
+	}
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	if b.Mode == Markdown {
+		b.mdgap()
+		if title != "" {
+			b.Printf("**%s**\n", title)
+		}
+		b.fence(lines)
+		return
+	}
+	inner := 0
+	for _, ln := range lines {
+		if w := dwidth(ln); w > inner {
+			inner = w
+		}
+	}
+	inner += 2 * pad
+	top := ""
+	if title != "" {
+		top = bc[4] + " " + title + " "
+		if w := dwidth(title) + 4; w > inner {
+			inner = w
+		}
+	}
+	top += strings.Repeat(bc[4], inner-dwidth(top))
+	b.Printf("%s%s%s\n", bc[0], top, bc[1])
+	sp := strings.Repeat(" ", pad)
+	for _, ln := range lines {
+		fill := strings.Repeat(" ", inner-2*pad-dwidth(ln))
+		b.Printf("%s%s%s%s%s%s\n", bc[5], sp, ln, fill, sp, bc[5])
+	}
+	b.Printf("%s%s%s\n", bc[2], strings.Repeat(bc[4], inner), bc[3])
+}
+
+// Method Frame writes content of the c buffer in a frame, as Box does.
+// Buffer c is left intact.
+func (b *Bld) Frame(c *Bld, title string, a ...interface{}) {
+	if c.sbu == nil {
+		c.autonew()
+	}
+	b.Box(title, c.String(), a...)
+}
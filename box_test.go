@@ -0,0 +1,27 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestBox(t *testing.T) {
+	bu := New(1)
+	bu.Box("", "hello\nwörld 日本\n")
+	exp := "┌────────────┐\n│ hello      │\n│ wörld 日本 │\n└────────────┘\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	in := New(1)
+	in.Printf("ok\n")
+	bu.Prefix("  ")
+	bu.Frame(&in, "Title", ASCII, 0)
+	bu.Box("T", "x", Double, 2)
+	exp = "  +- Title -+\n  |ok       |\n  +---------+\n" +
+		"  ╔═ T ═╗\n  ║  x  ║\n  ╚═════╝\n"
+	if bu.String() != exp || in.String() != "ok\n" {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,93 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ChannelEnv names the environment variable that lists enabled channels,
+// unless Channels was called. Eg. DEBUG=net,db* ./tool
+var ChannelEnv = "DEBUG"
+
+var chans struct {
+	sync.Mutex
+	init bool
+	pats []string        // patterns; "-pat" excludes
+	on   map[string]bool // cached matches
+	blds map[string]*Bld // channels made
+}
+
+// func Channel returns a zero buffer Bld for the named debug channel.
+// It prints, with "[name] " prefix, only if the channel is enabled by
+// a pattern given to Channels, or listed in the ChannelEnv variable.
+// Each call for the same name returns the same Bld. Its Printf (and
+// printers built on it), NL and ENL may be called from many goroutines;
+// other methods, like Prefix or Trace, may not. Eg. usage:
+//
+//	dbg := cout.Channel("net")
+//	dbg.Printf("dialing %s\n", addr) // prints: [net] dialing ...
+func Channel(name string) *Bld {
+	chans.Lock()
+	defer chans.Unlock()
+	if b := chans.blds[name]; b != nil {
+		return b
+	}
+	if chans.blds == nil {
+		chans.blds = make(map[string]*Bld)
+	}
+	b := New(0)
+	b.Prefix("[" + name + "] ")
+	b.tag = name
+	b.mu = new(sync.Mutex)
+	chans.blds[name] = &b
+	return &b
+}
+
+// func Channels enables channels whose names match any of comma or space
+// separated glob patterns (see path.Match). Pattern starting with a '-'
+// disables matching channels, eg. "*,-db.*" enables all but db ones.
+// Channels overrides the ChannelEnv variable; "" disables all channels.
+func Channels(patterns string) {
+	chans.Lock()
+	defer chans.Unlock()
+	chans.pats = splitpats(patterns)
+	chans.on = nil
+	chans.init = true
+}
+
+// Method On returns false for a Channel that is not enabled, true otherwise.
+// Use it to skip preparing output for disabled channels.
+func (b *Bld) On() bool { return b.tag == "" || chanon(b.tag) }
+
+// chanon tells whether named channel is enabled
+func chanon(name string) bool {
+	chans.Lock()
+	defer chans.Unlock()
+	if !chans.init {
+		chans.pats = splitpats(os.Getenv(ChannelEnv))
+		chans.init = true
+	}
+	if on, ok := chans.on[name]; ok {
+		return on
+	}
+	on := false
+	for _, p := range chans.pats {
+		neg := strings.HasPrefix(p, "-")
+		if ok, _ := path.Match(strings.TrimPrefix(p, "-"), name); ok {
+			on = !neg
+		}
+	}
+	if chans.on == nil {
+		chans.on = make(map[string]bool)
+	}
+	chans.on[name] = on
+	return on
+}
+
+func splitpats(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
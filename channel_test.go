@@ -0,0 +1,68 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestChannel(t *testing.T) {
+	sink := New(1)
+	defer func() { Capture = nil; Channels("") }()
+	Capture = &sink
+	Channels("tnet, tdb.*, -tdb.pool")
+	net, dbq, dbp, ui := Channel("tnet"), Channel("tdb.query"), Channel("tdb.pool"), Channel("tui")
+	if Channel("tnet") != net || !net.On() || ui.On() {
+		t.Logf("Expected the same channel Bld, and its On to tell the state")
+		t.Fail()
+	}
+	net.Printf("dial %s\n", "::1")
+	dbq.Printf("select")
+	dbq.NL()
+	dbp.Printf("conn\n")
+	dbp.ENL()
+	ui.Printf("draw\n")
+	ui.KV().Add("k", "v").Print()
+	ui.Mode = CSV
+	ui.Table(Col{Name: "a"}).Row(1).Print()
+	ui.Mode = Text
+	pw := ProgressWriter(ioutil.Discard, 4, ui)
+	io.WriteString(pw, "data")
+	Channels("tui")
+	ui.Printf("now on\n")
+	net.Printf("now off\n")
+	exp := "[tnet] dial ::1\n[tdb.query] select\n[tui] now on\n"
+	if sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+}
+
+func TestChannelConcurrent(t *testing.T) {
+	sink := New(1)
+	defer func() { Capture = nil; Channels("") }()
+	Capture = &sink
+	Channels("tpar")
+	ch := Channel("tpar")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ch.Printf("part ")
+				ch.Printf("line\n")
+				ch.NL()
+			}
+		}()
+	}
+	wg.Wait()
+	if n := strings.Count(sink.String(), "[tpar] "); n < 800 || n > 1600 {
+		t.Logf("Expected a prefix per line, got %d", n)
+		t.Fail()
+	}
+}
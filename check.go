@@ -0,0 +1,83 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// type Checker is a pass/fail reporter. It records labeled conditions,
+// prints a ✓ or ✗ line for each, and tallies them. Eg. usage:
+//
+//	ck := pb.Check()
+//	ck.That(err == nil, "config loads", err)
+//	ck.Thatf(n > 0, "has entries", "got %d", n)
+//	os.Exit(ck.Summary())
+type Checker struct {
+	Pass, Fail int // tallies
+	b          *Bld
+}
+
+// Method Check returns a Checker reporter that prints to b.
+func (b *Bld) Check() *Checker { return &Checker{b: b} }
+
+// Method That records condition c, printing "✓ label" if c is true, or
+// "✗ label" then details (formatted as by Print) indented, if c is false.
+// Returns c, as Pif does.
+func (ck *Checker) That(c bool, label string, details ...interface{}) bool {
+	var d string
+	if !c && len(details) > 0 {
+		d = fmt.Sprint(details...)
+	}
+	return ck.report(c, label, d)
+}
+
+// Method Thatf is That with details formatted as by Printf.
+func (ck *Checker) Thatf(c bool, label, fm string, a ...interface{}) bool {
+	var d string
+	if !c {
+		d = fmt.Sprintf(fm, a...)
+	}
+	return ck.report(c, label, d)
+}
+
+func (ck *Checker) report(c bool, label, details string) bool {
+	if c {
+		ck.Pass++
+		ck.b.printl("info", "✓ %s\n", label)
+		return c
+	}
+	ck.Fail++
+	ck.b.printl("error", "✗ %s\n", label)
+	if details == "" {
+		return c
+	}
+	for _, ln := range strings.Split(strings.TrimRight(details, "\n"), "\n") {
+		ck.b.printl("error", "    %s\n", ln)
+	}
+	return c
+}
+
+// Method Not records condition c that is expected to be false.
+// See That. Returns c, as PifNot does.
+func (ck *Checker) Not(c bool, label string, details ...interface{}) bool {
+	var d string
+	if c && len(details) > 0 {
+		d = fmt.Sprint(details...)
+	}
+	ck.report(!c, label, d)
+	return c
+}
+
+// Method Summary prints a summary line and returns a suggested exit
+// code: 0 if all checks passed, 1 otherwise.
+func (ck *Checker) Summary() int {
+	all := ck.Pass + ck.Fail
+	if ck.Fail == 0 {
+		ck.b.printl("info", "✓ all %d checks passed\n", all)
+		return 0
+	}
+	ck.b.printl("error", "✗ %d of %d checks failed\n", ck.Fail, all)
+	return 1
+}
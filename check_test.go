@@ -0,0 +1,35 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	bu := New(1)
+	ck := bu.Check()
+	if !ck.That(true, "passes") || !ck.Not(true, "fails", errors.New("boom")) {
+		t.Logf("Expected That and Not to return condition intact")
+		t.Fail()
+	}
+	ck.Thatf(false, "has entries", "got %d\nwant %d", 0, 3)
+	ck.Not(false, "no errors")
+	if code := ck.Summary(); code != 1 || ck.Pass != 2 || ck.Fail != 2 {
+		t.Logf("Expected exit code 1 and 2/2 tallies, got %d, %d/%d", code, ck.Pass, ck.Fail)
+		t.Fail()
+	}
+	exp := "✓ passes\n✗ fails\n    boom\n✗ has entries\n    got 0\n    want 3\n✓ no errors\n✗ 2 of 4 checks failed\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	ck = bu.Check()
+	ck.That(true, "ok")
+	if code := ck.Summary(); code != 0 || bu.String() != "✓ ok\n✓ all 1 checks passed\n" {
+		t.Logf("Expected exit code 0 and all passed, got %d, %q", code, bu.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,96 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// type Column wraps a Bld given to Columns, setting its width limit.
+type Column struct {
+	B     *Bld
+	Width int  // at most that many cells wide; 0 means no limit
+	Wrap  bool // wrap lines longer than Width, instead of truncating them
+}
+
+// Method Columns writes content of given buffers side by side, as columns
+// separated by gap spaces. Columns are padded to the display width of their
+// widest line. Each of cols is a Bld (or *Bld), or a Column (or *Column)
+// that also limits the width. Other values print as a "!BADCOL(type)"
+// column. Source buffers are left intact. Eg. usage:
+// `pb.Columns(3, &before, cout.Column{B: &after, Width: 40, Wrap: true})`
+func (b *Bld) Columns(gap int, cols ...interface{}) {
+	if gap < 0 {
+		gap = 0
+	}
+	var cells [][]string
+	var widths []int
+	rows := 0
+	for _, c := range cols {
+		var col Column
+		var lines []string
+		switch v := c.(type) {
+		case *Bld:
+			col.B = v
+		case Bld:
+			col.B = &v
+		case Column:
+			col = v
+		case *Column:
+			if v != nil {
+				col = *v
+			}
+		default:
+			lines = []string{fmt.Sprintf("!BADCOL(%T)", c)}
+		}
+		if col.B != nil && col.B.sbu != nil && col.B.Len() > 0 {
+			lines = strings.Split(strings.TrimRight(col.B.String(), "\n"), "\n")
+		}
+		if col.Width > 0 && col.Wrap {
+			lines = wrap(strings.Join(lines, "\n"), col.Width)
+		}
+		w := 0
+		for i, ln := range lines {
+			if col.Width > 0 {
+				ln = truncate(ln, col.Width)
+				lines[i] = ln
+			}
+			if lw := dwidth(ln); lw > w {
+				w = lw
+			}
+		}
+		if len(lines) > rows {
+			rows = len(lines)
+		}
+		cells = append(cells, lines)
+		widths = append(widths, w)
+	}
+	sep := strings.Repeat(" ", gap)
+	out := make([]string, 0, rows)
+	for r := 0; r < rows; r++ {
+		var ln strings.Builder
+		for i, lines := range cells {
+			cell := ""
+			if r < len(lines) {
+				cell = lines[r]
+			}
+			if i > 0 {
+				ln.WriteString(sep)
+			}
+			ln.WriteString(cell)
+			if i < len(cells)-1 {
+				ln.WriteString(strings.Repeat(" ", widths[i]-dwidth(cell)))
+			}
+		}
+		out = append(out, strings.TrimRight(ln.String(), " "))
+	}
+	if b.Mode == Markdown {
+		b.mdgap()
+		b.fence(out)
+		return
+	}
+	for _, ln := range out {
+		b.Printf("%s\n", ln)
+	}
+}
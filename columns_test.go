@@ -0,0 +1,41 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestColumns(t *testing.T) {
+	a, b, c := New(1), New(1), New(1)
+	a.Printf("left\ncolumn, wider\n")
+	b.Printf("日本\n\nthird\n")
+	c.Printf("this one is truncated\n")
+	bu := New(1)
+	bu.Columns(2, &a, &b, Column{B: &c, Width: 8})
+	exp := "left           日本   this on…\n" +
+		"column, wider\n" +
+		"               third\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%q\nbut got:\n%q", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Columns(1, Column{B: &c, Width: 10, Wrap: true}, &a)
+	exp = "this one  left\nis        column, wider\ntruncated\n"
+	if bu.String() != exp || a.Len() == 0 {
+		t.Logf("Expected:\n%q\nbut got:\n%q", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Columns(-1, &b, &b)
+	if exp = "日本 日本\n\nthirdthird\n"; bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Columns(1, a, &Column{B: &c, Width: 4}, 42)
+	exp = "left          thi… !BADCOL(int)\ncolumn, wider\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
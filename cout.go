@@ -12,21 +12,56 @@ Cout is meant as a toolbox helping us to fast write a PoC code and ad-hoc cli to
         pb.AutoNL = true   // Add a nl char to format strings lacking \n at end.
         pb.TrimTs = true   // Remove tail space (spaces to the newline char).
         pb.Prefix(string)  // Set a common text prefix to all next writes.
+        pb.Mode = mode     // cout.Text, Markdown, JSONL, CSV or TSV output.
+        pb.Verb = n        // verbosity threshold, a flag.Value (-v -v).
+        pb.Collapse(true)  // Print repeated lines once, with repeat count.
                            //
   pb.Out()                 // flush to stdout (or to the 'SetOut' io.Writer).
   pb.String()              // get buffer content as string (does not copy).
   pb.SetOut(io.Writer) ok  // set where Out will flush (overide default).
+  pb.HTML()                // content as <pre> HTML, ANSI colors to spans.
                            //
                            // Printers:
   pb.Printf(fmt, ...args)  // Printf that writes to the buffer.
           p := pb.Printf   // ...often used as "p": like p("please print").
   pb.Pif(c, fmt, ...) c    // writes if c bool condition is true. Returns c.
   pb.PifNot(c, fmt, ...) c //        if c bool condition is false. Returns c.
+  pb.With(k, v, ...) *Bld  // its printers add k=v fields to the output.
+  pb.V(n).Printf(fmt, ...) // prints only if pb.Verb >= n. Also V(n).Pif.
+  pb.Debugf(fmt, ...)      // prints if pb.Verb >= 1. Infof 0, Warnf -1...
   pb.Bar(n int, ti string) // writes "ti" titled divider, n characters wide.
   pb.NL()                  // amends buffer with an \n, if its not at the end.
   pb.ENL()                 // make sure buffer ends with an empty line.
   pb.CNL(c bool) c         // calls NL if c is true. Returns c.
   pb.CENL(c bool) c        // calls ENL if c is true. Returns c.
+                           //
+                           // Extras:
+  cout.ProgressReader(r, n, &pb) // wrap r so io.Copy draws progress bar on pb.
+  cout.ProgressWriter(w, n, &pb) // ...same for the writing side.
+  p("%v", cout.Bytes(n))         // 1.2 MiB; Count(n), Dur(d), Ago(t) too.
+  pb.Table(cols...).Row(..)      // rows under cout.Col headers; then Print().
+  pb.KV().Add(k, v).Print()      // aligned "key: value" block; Leader, Sort.
+  pb.List(cout.Numbers).Item(..) // also Bullets, Letters, Romans, Checks; Sub.
+  pb.Box(title, body, ...opt)    // framed text; pb.Frame(&child, title, ..opt).
+  pb.Columns(gap, &a, &b, ...)   // a, b content side by side; cout.Column caps.
+  pb.Logger(pfx, flags)          // *log.Logger printing to pb; pb.Writer() too.
+  cout.NewSlogHandler(&pb, opt)  // log/slog Handler printing to pb.
+  dbg := cout.Channel("net")     // "[net] " prints if DEBUG=net (or Channels).
+  pb.PrefixTpl("{time} {file} ") // prefix filled per line: time, elapsed, gid...
+  defer pb.Trace(args...)()      // → enter, indent output, ← leave with timing.
+  ck := pb.Check(); ck.That(c, l) // ✓/✗ label lines; os.Exit(ck.Summary()).
+  pb.PifL(c, fmt, func() []..)   // args made only if printed; PrintfL, V(n).PrintfL.
+  cout.Lazy(func() string {..})  // Stringer: text made only if it is printed.
+  pb.PrintOnce(key, fmt, ...)    // prints once per key. Returns true if printed.
+  pb.Every(n).Printf(fmt, ...)   // 1st, then every n-th call; Throttle(d) by time.
+  pb.Ring(lines, bytes)          // keep only the tail of content; older is omitted.
+  pb.Truncate(head, tail)        // keep first and last lines; middle is omitted.
+  cout.Register(&pb)             // FlushAll() flushes registered buffers;
+  defer cout.Guard()             // on panic too, then reports it. OnSignal().
+  pb.Err(err)                    // wrapped and joined errors as a tree.
+  pb.Stack()                     // caller's stack: func  dir/file.go:line
+  pb.Hijack(func() {..})         // os.Stdout and os.Stderr lines go to pb.
+  pb.Run(exec.Command(..))       // child's out|/err| lines, then exit status.
 */
 package cout
 
@@ -35,6 +70,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 )
 
 // Package config: MinSize of created buffer, and Capture io.Writer.
@@ -43,24 +79,50 @@ var (
 	MinSize = 1 << 8  // of buffer
 )
 
-// type Bld exposes cout API. It exposes also TrimTS, AutoNL, and Prefix(string) knobs.
+// type Bld exposes cout API. It exposes also TrimTS, AutoNL, Mode, Verb, and Prefix(string) knobs.
 type (
 	Bld struct { // use cout.New
-		*sbu             // our strings.Builder
-		size   int       // initial Builder size
-		AutoNL bool      // add newline unless fmt ends w/space or NL
-		TrimTs bool      // trim tailspace at Out() calling time
-		haspfx bool      // prefix on/off
-		skipfx bool      // skip prefix (call to call)
-		pfx    []byte    // Prefix with this if not in chain
-		to     io.Writer // printers write to
-		wout   io.Writer // Out() flushes to.
+		*sbu                 // our strings.Builder
+		size   int           // initial Builder size
+		AutoNL bool          // add newline unless fmt ends w/space or NL
+		TrimTs bool          // trim tailspace at Out() calling time
+		Mode   Mode          // Text, Markdown, or JSONL rendering
+		Verb   Verbosity     // threshold of leveled printers (V, Debugf...)
+		*line                // state shared with copies
+		haspfx bool          // prefix on/off
+		pfx    []byte        // Prefix with this if not in chain
+		ptpl   []pseg        // or with PrefixTpl parts, if set
+		ind    []byte        // Trace indent, after prefix
+		kv     []interface{} // With fields: key, value, ...
+		tag    string        // Channel name
+		mu     *sync.Mutex   // serializes Channel printers
+		dedup  bool          // Collapse repeated lines at Out
+		ring   *ring         // Ring limits, if set
+		to     io.Writer     // printers write to
+		wout   io.Writer     // Out() flushes to.
 	}
 	sbu = strings.Builder
+
+	// line is state of the output, shared by copies of a Bld (as With makes)
+	line struct {
+		skipfx bool   // skip prefix (call to call)
+		sect   string // section, as titled by Bar
+		gs     *gates // PrintOnce, Every, Throttle state
+	}
 )
 
 // see fmt.Printf docs
 func (b *Bld) Printf(fm string, a ...interface{}) {
+	if len(fm) == 0 || !b.On() {
+		return
+	}
+	b.print("info", fm, fmt.Sprintf(fm, a...))
+}
+
+// print writes s, that Printf made from fm, or that is given as is (then
+// fm is s too). Prefix, AutoNL and continuation depend on fm. In JSONL
+// mode s goes as a record of the level.
+func (b *Bld) print(level, fm, s string) {
 	end := len(fm) - 1
 	switch {
 	case end < 0:
@@ -68,16 +130,50 @@ func (b *Bld) Printf(fm string, a ...interface{}) {
 	case b.sbu == nil:
 		b.autonew()
 	}
+	if !b.On() {
+		return
+	}
+	if b.mu != nil { // Channel
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	if b.Mode == JSONL {
+		b.record(level, s, nil)
+		return
+	}
 	if b.haspfx && !b.skipfx && fm[0] != '\n' {
-		b.to.Write(b.pfx)
+		b.lead()
 	}
-	fmt.Fprintf(b.to, fm, a...)
+	if len(b.kv) > 0 {
+		s = b.withkv(s)
+	}
+	b.put(s)
 	if b.AutoNL && fm[end] != '\n' && fm[end] != ' ' {
-		b.NL()
+		b.nl()
 	}
 	b.skipfx = fm[end] == ' '
 }
 
+// put writes s where printers write. All output goes through put, so
+// muted channels print nothing, and Ring limits hold.
+func (b *Bld) put(s string) {
+	if !b.On() {
+		return
+	}
+	io.WriteString(b.to, s)
+	if b.ring != nil && b.Len() > b.ring.next {
+		b.trim()
+	}
+}
+
+// putw is put as io.Writer
+type putw struct{ b *Bld }
+
+func (w putw) Write(p []byte) (int, error) {
+	w.b.put(string(p))
+	return len(p), nil
+}
+
 // func cout.New returns wrapped strings.Builder of requested size
 // with added simple printers: Printf, Bar, NL, ENL - and their conditional
 // variants.  On returned Bld struct a complete strings.Builder API can be
@@ -97,6 +193,7 @@ func New(size int) Bld {
 	var cf Bld
 	var sb sbu
 	cf.sbu = &sb
+	cf.line = &line{}
 	cf.wout = Capture
 	if cf.wout == nil {
 		cf.wout = os.Stdout
@@ -120,6 +217,7 @@ func (b *Bld) autonew() {
 	}
 	var sb sbu
 	b.sbu = &sb
+	b.line = &line{}
 	b.wout = Capture
 	if b.wout == nil {
 		b.wout = os.Stdout
@@ -145,15 +243,24 @@ func (b *Bld) SetOut(w io.Writer) (ok bool) {
 // Method Out flushes buffer to the output Writer (ie. Capture, then Stdout)
 // then it calls Clear()
 func (b *Bld) Out() {
+	if dw, ok := b.to.(*dupw); ok {
+		dw.flush(true)
+	}
 	if b.sbu == nil || b.Cap() == 0 || b.Len() == 0 {
 		return
 	}
+	if b.ring != nil {
+		b.trim()
+	}
+	s, tol := b.String(), 0
+	if b.dedup {
+		s = collapse(s)
+	}
 	if !b.TrimTs {
-		fmt.Fprint(b.wout, b.String())
+		fmt.Fprint(b.wout, s)
 		b.Clear()
 		return
 	} // else trim all tails
-	s, tol := b.String(), 0
 	for {
 		if at := strings.Index(s, " \n"); at >= 0 {
 			for tol = at + 1; tol > 0 && s[tol-1] == ' '; tol-- {
@@ -178,6 +285,9 @@ func (b *Bld) Out() {
 // Method Clear removes content and sets buffer to its initial size
 // It does not touch other settings. Prefer Clear to Reset.
 func (b *Bld) Clear() {
+	if b.ring != nil {
+		b.ring.gone, b.ring.mark, b.ring.next = 0, "", 0
+	}
 	switch {
 	case b.sbu == nil:
 		b.autonew()
@@ -192,7 +302,10 @@ func (b *Bld) Clear() {
 // Method Prefix sets text to be prepended at whole output lines.
 // Set prefix does not print if current fmt string starts with a newline,
 // or previous fmt string ended with space.
-func (b *Bld) Prefix(pfx string) { b.pfx = []byte(pfx); b.haspfx = len(pfx) > 0 }
+func (b *Bld) Prefix(pfx string) {
+	b.pfx, b.ptpl = []byte(pfx), nil
+	b.haspfx = len(pfx) > 0 || len(b.ind) > 0
+}
 
 // Method Pif writes if the c condition is true. Returns c as given.
 func (b *Bld) Pif(c bool, fm string, a ...interface{}) bool {
@@ -219,15 +332,25 @@ func (b *Bld) PifNot(c bool, fm string, a ...interface{}) bool {
 func (b *Bld) NL() {
 	// somehow Pif/PifNot mostly dealt with '\n',
 	// make such usecases a single call
-	switch {
-	case b.sbu == nil:
+	if b.sbu == nil {
 		b.autonew()
-		fallthrough
+	}
+	if b.mu != nil { // Channel
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	b.nl()
+}
+
+func (b *Bld) nl() {
+	switch {
+	case b.Mode == JSONL, !b.On():
+		return
 	case b.to != b.sbu:
-		b.to.Write([]byte{'\n'})
+		b.put("\n")
 		b.skipfx = false
 	case b.Len() > 0 && b.String()[b.Len()-1] != '\n':
-		b.to.Write([]byte{'\n'})
+		b.put("\n")
 		b.skipfx = false
 	}
 }
@@ -239,12 +362,18 @@ func (b *Bld) ENL() {
 	const nl byte = '\n'
 	const nlnl string = "\n\n"
 	var b2, b1 byte
-	switch {
-	case b.sbu == nil:
+	if b.sbu == nil {
 		b.autonew()
-		fallthrough
+	}
+	if b.mu != nil { // Channel
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	switch {
+	case b.Mode == JSONL, !b.On():
+		return
 	case b.to != b.sbu:
-		b.to.Write([]byte(nlnl))
+		b.put(nlnl)
 		b.skipfx = false
 		return
 	case b.Len() == 0:
@@ -256,10 +385,10 @@ func (b *Bld) ENL() {
 	}
 	switch {
 	case b2 != nl && b1 == nl:
-		b.to.Write([]byte{nl})
+		b.put(string(nl))
 		b.skipfx = false
 	case b2 != nl && b1 != nl:
-		b.to.Write([]byte(nlnl))
+		b.put(nlnl)
 		b.skipfx = false
 	}
 }
@@ -300,7 +429,18 @@ func (b *Bld) Bar(a ...interface{}) { // title, width
 	if len(bstr) == 0 {
 		bstr = "="
 	}
-	tail := blen - len(bstr) - len(b.pfx)
+	switch b.Mode {
+	case Markdown:
+		b.mdbar(bstr)
+		return
+	case JSONL:
+		if b.sbu == nil {
+			b.autonew()
+		}
+		b.sect = bartitle(bstr)
+		return
+	}
+	tail := blen - len(bstr) - len(b.pfx) - len(b.ind)
 	if tail < 0 {
 		tail = 0
 	}
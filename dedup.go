@@ -0,0 +1,85 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Method Collapse turns on (or off) collapsing of repeated lines: a run of
+// identical lines prints once, followed by "... repeated 57 times" line.
+// Buffers collapse their content at Out time. Zero buffers collapse lines
+// as they are written - so a line prints only after its newline came, or
+// at Out call that flushes any pending one. Empty lines are kept as is.
+func (b *Bld) Collapse(on bool) {
+	if b.sbu == nil {
+		b.autonew()
+	}
+	b.dedup = on
+	switch dw, ok := b.to.(*dupw); {
+	case b.to == b.sbu:
+	case on && !ok:
+		b.to = &dupw{w: b.to}
+	case !on && ok:
+		dw.flush(true)
+		b.to = dw.w
+	}
+}
+
+// collapse returns s with runs of identical lines collapsed
+func collapse(s string) string {
+	var o strings.Builder
+	dw := dupw{w: &o}
+	io.WriteString(&dw, s)
+	dw.flush(true)
+	return o.String()
+}
+
+// dupw writes to w lines that differ from the previous one
+type dupw struct {
+	w    io.Writer
+	part []byte // incomplete line
+	last string // last line written
+	n    int    // times it was repeated
+}
+
+func (d *dupw) Write(p []byte) (int, error) {
+	d.part = append(d.part, p...)
+	for {
+		i := bytes.IndexByte(d.part, '\n')
+		if i < 0 {
+			break
+		}
+		ln := string(d.part[:i+1])
+		d.part = d.part[i+1:]
+		if ln == d.last && ln != "\n" {
+			d.n++
+			continue
+		}
+		d.flush(false)
+		d.last = ln
+		if _, err := io.WriteString(d.w, ln); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// flush writes the repeat count, and with all, incomplete line too
+func (d *dupw) flush(all bool) {
+	if d.n > 0 {
+		times := "times"
+		if d.n == 1 {
+			times = "time"
+		}
+		fmt.Fprintf(d.w, "... repeated %d %s\n", d.n, times)
+		d.n = 0
+	}
+	if all && len(d.part) > 0 {
+		d.w.Write(d.part)
+		d.last, d.part = "", d.part[:0]
+	}
+}
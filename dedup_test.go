@@ -0,0 +1,45 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestCollapse(t *testing.T) {
+	in := "a\na\na\nb\n\n\nb\nb\nc"
+	exp := "a\n... repeated 2 times\nb\n\n\nb\n... repeated 1 time\nc"
+	sink := New(1)
+	bu := New(1)
+	bu.SetOut(&sink)
+	bu.Collapse(true)
+	bu.Printf(in)
+	if bu.String() != in {
+		t.Logf("Expected buffer content intact until Out, got %q", bu.String())
+		t.Fail()
+	}
+	bu.Out()
+	if sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+	sink.Clear()
+	Capture = &sink
+	zb := New(0)
+	Capture = nil
+	zb.Collapse(true)
+	for i := 0; i < 3; i++ {
+		zb.Printf("x\n")
+	}
+	if exp := "x\n"; sink.String() != exp {
+		t.Logf("Expected streamed %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+	zb.Printf("y")
+	zb.Out()
+	zb.Collapse(false)
+	zb.Printf("y\n")
+	zb.Printf("y\n")
+	if exp := "x\n... repeated 2 times\nyy\ny\n"; sink.String() != exp {
+		t.Logf("Expected streamed %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+}
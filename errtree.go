@@ -0,0 +1,142 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Method Err prints err and errors it wraps, found by Unwrap() error or
+// Unwrap() []error methods (of fmt.Errorf %w, errors.Join, and alike), as
+// a tree. Each node shows its own message, without the wrapped one:
+//
+//	load config
+//	└─ open app.yaml
+//	   └─ permission denied
+//
+// Nil err prints nothing. In JSONL mode err prints as a single "error"
+// level record with full message; in Markdown mode tree is fenced.
+func (b *Bld) Err(err error) {
+	if err == nil {
+		return
+	}
+	if b.Mode == JSONL {
+		b.record("error", err.Error(), nil)
+		return
+	}
+	lines := errtree(nil, err, "", "", 0)
+	if b.Mode == Markdown {
+		b.mdgap()
+		b.fence(lines)
+		return
+	}
+	for _, ln := range lines {
+		b.Printf("%s\n", ln)
+	}
+}
+
+// errtree appends lines of err node and of its children
+func errtree(lines []string, err error, lead, next string, depth int) []string {
+	var kids []error
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, k := range e.Unwrap() {
+			if k != nil {
+				kids = append(kids, k)
+			}
+		}
+	case interface{ Unwrap() error }:
+		if k := e.Unwrap(); k != nil {
+			kids = append(kids, k)
+		}
+	}
+	msg := err.Error()
+	switch {
+	case len(kids) == 1 && msg != kids[0].Error():
+		msg = strings.TrimSuffix(msg, kids[0].Error())
+		msg = strings.TrimRight(msg, " :")
+	case len(kids) > 1:
+		km := make([]string, len(kids))
+		for i, k := range kids {
+			km[i] = k.Error()
+		}
+		if msg == strings.Join(km, "\n") { // errors.Join
+			msg = fmt.Sprintf("%d errors", len(kids))
+		}
+	}
+	if nl := strings.IndexByte(msg, '\n'); nl >= 0 {
+		msg = msg[:nl] + " …"
+	}
+	lines = append(lines, lead+msg)
+	if depth >= 32 { // cycle guard
+		return lines
+	}
+	for i, k := range kids {
+		if i == len(kids)-1 {
+			lines = errtree(lines, k, next+"└─ ", next+"   ", depth+1)
+		} else {
+			lines = errtree(lines, k, next+"├─ ", next+"│  ", depth+1)
+		}
+	}
+	return lines
+}
+
+// Method Stack prints stack of the calling goroutine, one frame a line,
+// function names aligned with their dir/file.go:line locations:
+//
+//	main.load       app/main.go:42
+//	main.main       app/main.go:17
+//
+// Frames of Go runtime are left out. In JSONL mode stack prints as a single
+// record, in Markdown mode it is fenced.
+func (b *Bld) Stack() {
+	lines := stack(3)
+	switch b.Mode {
+	case JSONL:
+		b.record("info", strings.Join(lines, "\n"), nil)
+	case Markdown:
+		b.mdgap()
+		b.fence(lines)
+	default:
+		for _, ln := range lines {
+			b.Printf("%s\n", ln)
+		}
+	}
+}
+
+// stack returns formatted frames of the current goroutine, skipping skip
+// callers (as runtime.Callers does) and runtime frames
+func stack(skip int) []string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip, pcs)])
+	var fns, locs []string
+	fw := 0
+	for {
+		fr, more := frames.Next()
+		if fr.Function != "" && !strings.HasPrefix(fr.Function, "runtime.") {
+			fn := shortfunc(fr.Function)
+			if w := len(fn); w > fw {
+				fw = w
+			}
+			fns = append(fns, fn)
+			locs = append(locs, fmt.Sprintf("%s:%d", shortpath(fr.File), fr.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	lines := make([]string, len(fns))
+	for i := range fns {
+		lines[i] = fmt.Sprintf("%-*s  %s", fw, fns[i], locs[i])
+	}
+	return lines
+}
+
+// shortpath keeps only the last dir and the file name
+func shortpath(fp string) string {
+	dir, file := filepath.Split(fp)
+	return filepath.Join(filepath.Base(dir), file)
+}
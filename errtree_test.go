@@ -0,0 +1,63 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type joined []error
+
+func (j joined) Error() string {
+	s := make([]string, len(j))
+	for i, e := range j {
+		s[i] = e.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (j joined) Unwrap() []error { return j }
+
+func TestErr(t *testing.T) {
+	bu := New(1)
+	perm := errors.New("permission denied")
+	eof := errors.New("unexpected EOF")
+	err := fmt.Errorf("load config: %w", joined{
+		fmt.Errorf("open app.yaml: %w", perm),
+		fmt.Errorf("parse defaults: %w", eof),
+		errors.New("no user dir"),
+	})
+	bu.Err(err)
+	bu.Err(nil)
+	exp := `load config
+└─ 3 errors
+   ├─ open app.yaml
+   │  └─ permission denied
+   ├─ parse defaults
+   │  └─ unexpected EOF
+   └─ no user dir
+`
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+}
+
+func TestStack(t *testing.T) {
+	bu := New(1)
+	func() { bu.Stack() }()
+	loc := filepath.Base(pkgdir) + "/errtree_test.go:"
+	lines := strings.Split(strings.TrimRight(bu.String(), "\n"), "\n")
+	if len(lines) < 3 ||
+		!strings.HasPrefix(lines[0], "cout.TestStack.func1  "+loc) ||
+		!strings.HasPrefix(lines[1], "cout.TestStack        "+loc) ||
+		!strings.HasPrefix(lines[2], "testing.tRunner       testing/testing.go:") ||
+		strings.Contains(bu.String(), "runtime.") {
+		t.Logf("Unexpected stack:\n%s", bu.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,116 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+var live struct {
+	sync.Mutex
+	blds []*Bld // registered, in order
+}
+
+// panic and signal reports go there, replaced in tests
+var (
+	stderr io.Writer = os.Stderr
+	exit             = os.Exit
+)
+
+// func Register adds buffers to the set flushed by FlushAll, Guard and
+// OnSignal. Registering the same Bld again is a no-op. Eg. usage:
+//
+//	pb := cout.New(0)
+//	cout.Register(&pb)
+//	defer cout.Guard()
+func Register(bs ...*Bld) {
+	live.Lock()
+	defer live.Unlock()
+next:
+	for _, b := range bs {
+		for _, r := range live.blds {
+			if r == b {
+				continue next
+			}
+		}
+		live.blds = append(live.blds, b)
+	}
+}
+
+// func Unregister removes buffers from the set flushed by FlushAll.
+func Unregister(bs ...*Bld) {
+	live.Lock()
+	defer live.Unlock()
+	for _, b := range bs {
+		for i, r := range live.blds {
+			if r == b {
+				live.blds = append(live.blds[:i], live.blds[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// func FlushAll calls Out on every registered buffer, in order they were
+// registered. Bld is not goroutine safe, so FlushAll should not run while
+// other goroutines still write to registered buffers.
+func FlushAll() {
+	live.Lock()
+	bs := append([]*Bld(nil), live.blds...)
+	live.Unlock()
+	for _, b := range bs {
+		b.Out()
+	}
+}
+
+// func Guard, if deferred at the top of main (or of a goroutine), flushes
+// all registered buffers when panic unwinds through it, then reports the
+// panic message with stack (as Stack prints it) to stderr, and panics again
+// with the same value.
+func Guard() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	FlushAll()
+	fmt.Fprintf(stderr, "panic: %v\n\n%s\n", r, strings.Join(stack(3), "\n"))
+	panic(r)
+}
+
+// func OnSignal makes the program flush all registered buffers, then exit
+// with 128+signal code, on SIGINT or SIGTERM (or on given sigs, if any).
+// Returned stop func restores default handling of these signals.
+func OnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ch := make(chan os.Signal, 1)
+	quit := make(chan struct{})
+	signal.Notify(ch, sigs...)
+	go func() {
+		select {
+		case sig := <-ch:
+			FlushAll()
+			fmt.Fprintf(stderr, "\nsignal: %v\n", sig)
+			code := 1
+			if s, ok := sig.(syscall.Signal); ok {
+				code = 128 + int(s)
+			}
+			exit(code)
+		case <-quit:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(quit)
+		})
+	}
+}
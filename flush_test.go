@@ -0,0 +1,82 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlushAll(t *testing.T) {
+	var errs strings.Builder
+	stderr = &errs
+	defer func() { stderr = os.Stderr }()
+	sink := New(1)
+	a, b := New(1), New(1)
+	a.SetOut(&sink)
+	b.SetOut(&sink)
+	Register(&a, &b, &a)
+	defer Unregister(&a, &b)
+	a.Printf("a\n")
+	b.Printf("b\n")
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Logf("Expected re-panic with %q, got %v", "boom", r)
+				t.Fail()
+			}
+		}()
+		defer Guard()
+		panic("boom")
+	}()
+	if exp := "a\nb\n"; sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+	loc := "  " + filepath.Base(pkgdir) + "/flush_test.go:"
+	if !strings.HasPrefix(errs.String(), "panic: boom\n\ncout.TestFlushAll.func2"+loc) {
+		t.Logf("Expected panic report, but got %q", errs.String())
+		t.Fail()
+	}
+	Unregister(&b)
+	a.Printf("a2\n")
+	b.Printf("b2\n")
+	FlushAll()
+	if exp := "a\nb\na2\n"; sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+}
+
+func TestOnSignal(t *testing.T) {
+	var errs strings.Builder
+	stderr = &errs
+	codes := make(chan int, 1)
+	exit = func(code int) { codes <- code }
+	defer func() { stderr, exit = os.Stderr, os.Exit }()
+	sink := New(1)
+	a := New(1)
+	a.SetOut(&sink)
+	Register(&a)
+	defer Unregister(&a)
+	a.Printf("pending\n")
+	stop := OnSignal(os.Interrupt)
+	defer stop()
+	p, _ := os.FindProcess(os.Getpid())
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Skipf("can not signal self: %v", err)
+	}
+	select {
+	case code := <-codes:
+		if code != 130 || sink.String() != "pending\n" {
+			t.Logf("Expected flush and exit 130, got %d %q", code, sink.String())
+			t.Fail()
+		}
+	case <-time.After(5 * time.Second):
+		t.Logf("Signal was not handled")
+		t.Fail()
+	}
+}
@@ -0,0 +1,113 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// gates keeps state of PrintOnce, Every and Throttle
+type gates struct {
+	once  map[string]bool
+	sites map[uintptr]*gsite // by call site
+}
+
+type gsite struct {
+	calls int       // Every: calls so far
+	last  time.Time // Throttle: last open
+	supp  int       // suppressed since last open
+}
+
+// Method PrintOnce prints only the first time it is called with the key.
+// Returns true if it printed. Eg. usage in a loop:
+// `pb.PrintOnce("deprecated", "warning: option -x is deprecated\n")`
+func (b *Bld) PrintOnce(key, fm string, a ...interface{}) bool {
+	if b.sbu == nil {
+		b.autonew()
+	}
+	if b.gs == nil {
+		b.gs = &gates{}
+	}
+	if b.gs.once[key] {
+		return false
+	}
+	if b.gs.once == nil {
+		b.gs.once = make(map[string]bool)
+	}
+	b.gs.once[key] = true
+	b.Printf(fm, a...)
+	return true
+}
+
+// Method Every returns a Gate that is open on the first, then on every n-th
+// call from the same place in code. Open gate's message tells how many
+// were suppressed. Eg. usage in a loop:
+// `pb.Every(1000).Printf("%d rows so far\n", n)`
+func (b *Bld) Every(n int) Gate {
+	gs := b.site(2)
+	gs.calls++
+	if n > 1 && (gs.calls-1)%n != 0 {
+		gs.supp++
+		return Gate{}
+	}
+	return b.open(gs)
+}
+
+// Method Throttle returns a Gate that is open if it was not open for the
+// d duration, for calls from the same place in code. Open gate's message
+// tells how many were suppressed. Eg. usage in a loop:
+// `pb.Throttle(time.Second).Printf("retrying: %v\n", err)`
+func (b *Bld) Throttle(d time.Duration) Gate {
+	gs := b.site(2)
+	if t := now(); gs.last.IsZero() || t.Sub(gs.last) >= d {
+		gs.last = t
+		return b.open(gs)
+	}
+	gs.supp++
+	return Gate{}
+}
+
+// site returns state for the caller 'up' frames above
+func (b *Bld) site(up int) *gsite {
+	pc, _, _, _ := runtime.Caller(up)
+	if b.sbu == nil {
+		b.autonew()
+	}
+	if b.gs == nil {
+		b.gs = &gates{}
+	}
+	if b.gs.sites == nil {
+		b.gs.sites = make(map[uintptr]*gsite)
+	}
+	gs := b.gs.sites[pc]
+	if gs == nil {
+		gs = &gsite{}
+		b.gs.sites[pc] = gs
+	}
+	return gs
+}
+
+// open returns open Gate, with a note about suppressed calls, if any
+func (b *Bld) open(gs *gsite) Gate {
+	g := Gate{b: b, lv: "info"}
+	if gs.supp > 0 {
+		g.note = fmt.Sprintf("(suppressed %d similar)", gs.supp)
+		gs.supp = 0
+	}
+	return g
+}
+
+// emit prints for an open Gate, with its note put before trailing newlines
+func (g Gate) emit(fm string, a []interface{}) {
+	if g.note == "" {
+		g.b.printl(g.lv, fm, a...)
+		return
+	}
+	s := fmt.Sprintf(fm, a...)
+	body := strings.TrimRight(s, "\n")
+	s = body + " " + g.note + s[len(body):]
+	g.b.print(g.lv, s, s)
+}
@@ -0,0 +1,32 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGates(t *testing.T) {
+	defer fakeClock(400 * time.Millisecond)()
+	bu := New(1)
+	for i := 1; i <= 7; i++ {
+		bu.PrintOnce("k", "once %d\n", i)
+		bu.Every(3).Printf("every %d\n", i)
+		bu.Throttle(time.Second).Printf("throttle %d, 100%%\n", i)
+	}
+	if bu.PrintOnce("k", "again\n") || !bu.PrintOnce("k2", "other key\n") {
+		t.Logf("Expected PrintOnce to tell whether it printed")
+		t.Fail()
+	}
+	exp := "once 1\nevery 1\nthrottle 1, 100%\n" +
+		"every 4 (suppressed 2 similar)\n" +
+		"throttle 4, 100% (suppressed 2 similar)\n" +
+		"every 7 (suppressed 2 similar)\n" +
+		"throttle 7, 100% (suppressed 2 similar)\n" +
+		"other key\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+}
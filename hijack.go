@@ -0,0 +1,71 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sync"
+)
+
+// only one Hijack at a time may swap os.Stdout and os.Stderr
+var hijack sync.Mutex
+
+// Method Hijack runs f with os.Stdout and os.Stderr redirected through
+// an os.Pipe into b, so output of dependencies that print directly can be
+// collected (eg. to be checked in tests). Each line is printed as with
+// b.Printf, so it gets b's prefix. Both streams go to b in order they were
+// written. Eg. usage:
+//
+//	out := cout.New(1)
+//	out.Prefix("  | ")
+//	err := out.Hijack(func() { thirdparty.Run() })
+//
+// Writers that have copied os.Stdout or os.Stderr before the call, like
+// default log package Logger or zero buffers made by New, are not affected.
+// Do not use b in f, nor from other goroutines, until Hijack returns.
+func (b *Bld) Hijack(f func()) (err error) {
+	if b.sbu == nil {
+		b.autonew() // takes os.Stdout now, not from the pipe
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	hijack.Lock()
+	defer hijack.Unlock()
+	done := make(chan error, 1)
+	go func() {
+		done <- b.lines(r)
+		r.Close()
+	}()
+	stdout, stderr := os.Stdout, os.Stderr
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		w.Close()
+		if rerr := <-done; err == nil {
+			err = rerr
+		}
+	}()
+	os.Stdout, os.Stderr = w, w
+	f()
+	return err
+}
+
+// lines prints lines read from r into b, until EOF
+func (b *Bld) lines(r io.Reader) error {
+	br := bufio.NewReader(r)
+	for {
+		ln, err := br.ReadString('\n')
+		if ln != "" {
+			b.Printf("%s", ln)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestHijack(t *testing.T) {
+	stdout := os.Stdout
+	bu := New(1)
+	bu.Prefix("  | ")
+	err := bu.Hijack(func() {
+		fmt.Println("to stdout")
+		fmt.Fprintln(os.Stderr, "to stderr")
+		fmt.Print("no newline")
+	})
+	exp := "  | to stdout\n  | to stderr\n  | no newline"
+	if err != nil || bu.String() != exp {
+		t.Logf("Expected %q, but got %q (err %v)", exp, bu.String(), err)
+		t.Fail()
+	}
+	if os.Stdout != stdout {
+		t.Logf("os.Stdout was not restored")
+		t.Fail()
+	}
+}
+
+func TestHijackZero(t *testing.T) {
+	f, err := ioutil.TempFile("", "cout")
+	if err != nil {
+		t.Skipf("no temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	stdout := os.Stdout
+	os.Stdout = f
+	var zb Bld
+	err = zb.Hijack(func() { fmt.Println("via zero Bld") })
+	os.Stdout = stdout
+	f.Close()
+	got, _ := ioutil.ReadFile(f.Name())
+	if exp := "via zero Bld\n"; err != nil || string(got) != exp {
+		t.Logf("Expected %q, but got %q (err %v)", exp, got, err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,190 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// Method HTML returns buffer content as a <pre class="cout"> HTML fragment.
+// ANSI SGR sequences (bold, italic, underline, 16, 256 and rgb colors)
+// it contains are converted to styled spans; other escapes are dropped.
+func (b *Bld) HTML() string {
+	if b.sbu == nil {
+		b.autonew()
+	}
+	return ansi2html(b.String())
+}
+
+// Method HTMLPage returns buffer content as a standalone HTML page,
+// titled with title. See HTML.
+func (b *Bld) HTMLPage(title string) string {
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n" +
+		"<title>" + html.EscapeString(title) + "</title>\n" +
+		"<style>\nbody { background: #1e1e1e; color: #d4d4d4; }\n" +
+		"pre.cout { font-family: monospace; line-height: 1.2; }\n</style>\n" +
+		"</head>\n<body>\n" + b.HTML() + "\n</body>\n</html>\n"
+}
+
+// sgr keeps state of text attributes
+type sgr struct {
+	fg, bg                 string
+	bold, dim, ital, under bool
+}
+
+func (a sgr) css() string {
+	var c []string
+	if a.fg != "" {
+		c = append(c, "color:"+a.fg)
+	}
+	if a.bg != "" {
+		c = append(c, "background-color:"+a.bg)
+	}
+	if a.bold {
+		c = append(c, "font-weight:bold")
+	}
+	if a.dim {
+		c = append(c, "opacity:0.6")
+	}
+	if a.ital {
+		c = append(c, "font-style:italic")
+	}
+	if a.under {
+		c = append(c, "text-decoration:underline")
+	}
+	return strings.Join(c, ";")
+}
+
+// apply updates attributes by SGR parameters, eg. "1;38;5;208"
+func (a *sgr) apply(params string) {
+	ps := strings.Split(params, ";")
+	for i := 0; i < len(ps); i++ {
+		n, _ := strconv.Atoi(ps[i]) // empty is 0
+		switch {
+		case n == 0:
+			*a = sgr{}
+		case n == 1:
+			a.bold = true
+		case n == 2:
+			a.dim = true
+		case n == 3:
+			a.ital = true
+		case n == 4:
+			a.under = true
+		case n == 22:
+			a.bold, a.dim = false, false
+		case n == 23:
+			a.ital = false
+		case n == 24:
+			a.under = false
+		case n >= 30 && n <= 37:
+			a.fg = ansicolor(n - 30)
+		case n >= 90 && n <= 97:
+			a.fg = ansicolor(n - 90 + 8)
+		case n == 39:
+			a.fg = ""
+		case n >= 40 && n <= 47:
+			a.bg = ansicolor(n - 40)
+		case n >= 100 && n <= 107:
+			a.bg = ansicolor(n - 100 + 8)
+		case n == 49:
+			a.bg = ""
+		case n == 38, n == 48:
+			var col string
+			col, i = extcolor(ps, i)
+			if n == 38 {
+				a.fg = col
+			} else {
+				a.bg = col
+			}
+		}
+	}
+}
+
+// extcolor parses 5;n or 2;r;g;b that follows 38 or 48 at ps[i]
+func extcolor(ps []string, i int) (string, int) {
+	arg := func(k int) int {
+		if i+k >= len(ps) {
+			return 0
+		}
+		n, _ := strconv.Atoi(ps[i+k])
+		return n & 0xff
+	}
+	if i+1 >= len(ps) {
+		return "", i
+	}
+	switch ps[i+1] {
+	case "5":
+		return ansicolor(arg(2)), i + 2
+	case "2":
+		return fmt.Sprintf("#%02x%02x%02x", arg(2), arg(3), arg(4)), i + 4
+	}
+	return "", i + 1
+}
+
+// xterm default palette of the first 16 colors
+var palette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansicolor returns css color of n-th of 256 colors
+func ansicolor(n int) string {
+	switch {
+	case n < 16:
+		return palette[n]
+	case n < 232:
+		n -= 16
+		lv := func(c int) int {
+			if c == 0 {
+				return 0
+			}
+			return 55 + c*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", lv(n/36), lv(n/6%6), lv(n%6))
+	}
+	g := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", g, g, g)
+}
+
+func ansi2html(s string) string {
+	var o strings.Builder
+	var at sgr
+	span := "" // style of the open span
+	text := func(t string) {
+		if t == "" {
+			return
+		}
+		if css := at.css(); css != span {
+			if span != "" {
+				o.WriteString("</span>")
+			}
+			if span = css; css != "" {
+				o.WriteString(`<span style="` + css + `">`)
+			}
+		}
+		o.WriteString(html.EscapeString(t))
+	}
+	o.WriteString(`<pre class="cout">`)
+	for len(s) > 0 {
+		esc := strings.IndexByte(s, 0x1b)
+		if esc < 0 {
+			text(s)
+			break
+		}
+		text(s[:esc])
+		end := skipesc(s, esc)
+		if seq := s[esc:end]; len(seq) > 2 && seq[1] == '[' && seq[len(seq)-1] == 'm' {
+			at.apply(seq[2 : len(seq)-1])
+		}
+		s = s[end:]
+	}
+	if span != "" {
+		o.WriteString("</span>")
+	}
+	o.WriteString("</pre>")
+	return o.String()
+}
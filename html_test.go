@@ -0,0 +1,29 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	bu := New(1)
+	bu.Printf("plain <&>\n\x1b[1;31mbold red\x1b[0m \x1b[38;5;208m\x1b[4mo\x1b[24mk\x1b[39m\x1b[K\n")
+	bu.Printf("\x1b[48;2;1;2;3mrgb\x1b[m.")
+	exp := `<pre class="cout">plain &lt;&amp;&gt;` + "\n" +
+		`<span style="color:#cd0000;font-weight:bold">bold red</span> ` +
+		`<span style="color:#ff8700;text-decoration:underline">o</span>` +
+		`<span style="color:#ff8700">k</span>` + "\n" +
+		`<span style="background-color:#010203">rgb</span>.</pre>`
+	if got := bu.HTML(); got != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, got)
+		t.Fail()
+	}
+	pg := bu.HTMLPage("CI <report>")
+	if !strings.HasPrefix(pg, "<!DOCTYPE html>") || !strings.Contains(pg, "<title>CI &lt;report&gt;</title>") ||
+		!strings.Contains(pg, exp) {
+		t.Logf("Unexpected page:\n%s", pg)
+		t.Fail()
+	}
+}
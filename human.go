@@ -0,0 +1,181 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Human readable values for use with Printf and friends. Each implements
+// fmt.Formatter: %v and %s print it for humans, width and '-' flag pad as
+// usual, precision sets number of decimals; %d prints raw number.
+//
+//	p("%v of %v", cout.Bytes(n), cout.Bytes(total)) // 1.2 MiB of 3.0 MiB
+//	p("%+.2v", cout.Bytes(n))                       // SI units: 1.26 MB
+//	p("%v rows", cout.Count(12345))                 // 12,345 rows
+//	p("%+v rows", cout.Count(12345))                // 12.3k rows
+//	p("took %v", cout.Dur(d))                       // took 3m12s
+//	p("seen %v", cout.Ago(t))                       // seen 3m12s ago
+type (
+	Bytes int64         // size: IEC (KiB, MiB); with '+' flag SI (kB, MB)
+	Count int64         // with thousands separators; with '+' short: 12.3k
+	Dur   time.Duration // rounded to what humans care about: 3m12s, 1.5s
+	Ago   time.Time     // time distance to now: 3m12s ago, or in 2h5m
+)
+
+// Method Format implements fmt.Formatter.
+func (n Bytes) Format(f fmt.State, verb rune) {
+	if verb == 'd' {
+		fmt.Fprintf(f, respec(f, verb), int64(n))
+		return
+	}
+	pad(f, hbytes(int64(n), f.Flag('+'), prec(f, 1)))
+}
+
+// Method Format implements fmt.Formatter.
+func (n Count) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'd':
+		fmt.Fprintf(f, respec(f, verb), int64(n))
+	case f.Flag('+'):
+		pad(f, hcount(int64(n), prec(f, 1)))
+	default:
+		pad(f, commas(int64(n)))
+	}
+}
+
+// Method Format implements fmt.Formatter.
+func (d Dur) Format(f fmt.State, verb rune) {
+	if verb == 'd' {
+		fmt.Fprintf(f, respec(f, verb), int64(d))
+		return
+	}
+	pad(f, hdur(time.Duration(d)))
+}
+
+// Method Format implements fmt.Formatter.
+func (t Ago) Format(f fmt.State, verb rune) {
+	d := now().Sub(time.Time(t))
+	switch {
+	case verb == 'd':
+		fmt.Fprintf(f, respec(f, verb), int64(d))
+	case d == math.MinInt64: // -d would overflow
+		pad(f, "in "+hdur(math.MaxInt64))
+	case d < 0:
+		pad(f, "in "+hdur(-d))
+	default:
+		pad(f, hdur(d)+" ago")
+	}
+}
+
+// prec returns precision asked for, or def
+func prec(f fmt.State, def int) int {
+	if p, ok := f.Precision(); ok {
+		return p
+	}
+	return def
+}
+
+// pad writes s padded to the width asked for
+func pad(f fmt.State, s string) {
+	w, ok := f.Width()
+	if n := w - dwidth(s); ok && n > 0 {
+		if f.Flag('-') {
+			s += strings.Repeat(" ", n)
+		} else {
+			s = strings.Repeat(" ", n) + s
+		}
+	}
+	f.Write([]byte(s))
+}
+
+// respec rebuilds format spec given to the Formatter
+func respec(f fmt.State, verb rune) string {
+	spec := []byte{'%'}
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			spec = append(spec, byte(c))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		spec = strconv.AppendInt(spec, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		spec = append(spec, '.')
+		spec = strconv.AppendInt(spec, int64(p), 10)
+	}
+	return string(append(spec, string(verb)...))
+}
+
+// commas returns n with thousands separated: 12,345
+func commas(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
+
+// hcount returns n short with SI suffix: 12.3k, 4.5M
+func hcount(n int64, prec int) string {
+	if n < 1000 && n > -1000 {
+		return strconv.FormatInt(n, 10)
+	}
+	div, exp := int64(1000), 0
+	for x := n / 1000; x >= 1000 || x <= -1000; x /= 1000 {
+		div *= 1000
+		exp++
+	}
+	return fmt.Sprintf("%.*f%c", prec, float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+// hbytes returns human readable size, IEC (KiB) or SI (kB) units.
+func hbytes(n int64, si bool, prec int) string {
+	unit, pfx := int64(1024), "KMGTPE"
+	if si {
+		unit = 1000
+	}
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for x := n / unit; x >= unit || x <= -unit; x /= unit {
+		div *= unit
+		exp++
+	}
+	u := pfx[exp]
+	if si {
+		if u == 'K' {
+			u = 'k'
+		}
+		return fmt.Sprintf("%.*f %cB", prec, float64(n)/float64(div), u)
+	}
+	return fmt.Sprintf("%.*f %ciB", prec, float64(n)/float64(div), u)
+}
+
+// hdur returns duration rounded to be read by humans: 3m12s, 1.5s, 120ms.
+func hdur(d time.Duration) string {
+	switch {
+	case d == math.MinInt64: // -d would overflow
+		return "-" + hdur(math.MaxInt64)
+	case d < 0:
+		return "-" + hdur(-d)
+	case d < time.Millisecond:
+		return d.String()
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	case d < time.Hour:
+		return d.Round(time.Second).String()
+	}
+	return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+}
@@ -0,0 +1,35 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHumanVerbs(t *testing.T) {
+	defer fakeClock(0)()
+	t0 := now()
+	bu := New(1)
+	p := bu.Printf
+	p("[%v] [%+v] [%.2v] [%d]\n", Bytes(1536), Bytes(1536), Bytes(1<<30), Bytes(1536))
+	p("[%v] [%v] [%+v] [%+.0v] [%7d]\n", Count(12345), Count(-1234567), Count(12345), Count(2e6), Count(42))
+	p("[%v] [%-6v] [%8v]\n", Dur(3*time.Minute+12*time.Second+400*time.Millisecond), Dur(1500*time.Millisecond), Dur(2*time.Hour+5*time.Minute))
+	p("[%v] [%v]\n", Ago(t0.Add(-90*time.Second)), Ago(t0.Add(time.Minute)))
+	p("[%8v] [%v] [%v] [%v]\n", Dur(1500), Dur(math.MinInt64), Ago(time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC)), Ago(t0.Add(500)))
+	exp := "[1.5 KiB] [1.5 kB] [1.00 GiB] [1536]\n" +
+		"[12,345] [-1,234,567] [12.3k] [2M] [     42]\n" +
+		"[3m12s] [1.5s  ] [    2h5m]\n" +
+		"[1m30s ago] [in 1m0s]\n" +
+		"[   1.5µs] [-2562047h47m] [in 2562047h47m] [in 500ns]\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+	if s := fmt.Sprint(Count(999)); s != "999" {
+		t.Logf("Expected Sprint to use Format, got %q", s)
+		t.Fail()
+	}
+}
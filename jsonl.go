@@ -0,0 +1,126 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// In JSONL mode each Printf (Pif, PifNot) call writes a single JSON object
+// line, instead of text:
+//
+//	{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"loaded","section":"Config","file":"a.conf"}
+//
+// Message has its trailing newlines removed. Section is the title of the
+// last titled Bar; Bar itself, NL and ENL print nothing. Fields come from
+// With. KV prints a single object with its pairs as fields.
+
+// Method With returns a copy of b, printing into the same buffer, whose
+// printers add given key, value pairs: as fields of JSONL objects, or as
+// key=value to the text. Eg. `pb.With("file", fn, "n", n).Printf("loaded")`
+// The copy shares line state with b: prefix continuation, Bar section, and
+// PrintOnce, Every and Throttle counters.
+func (b *Bld) With(kv ...interface{}) *Bld {
+	if b.sbu == nil {
+		b.autonew()
+	}
+	c := *b
+	c.kv = append(b.kv[:len(b.kv):len(b.kv)], kv...)
+	return &c
+}
+
+// withkv appends " key=value" fields to the text s, before its newlines.
+func (b *Bld) withkv(s string) string {
+	body := strings.TrimRight(s, "\n")
+	var o strings.Builder
+	o.WriteString(body)
+	b.eachkv(nil, func(k string, v interface{}) {
+		o.WriteString(" " + k + "=" + kvtext(v))
+	})
+	return o.String() + s[len(body):]
+}
+
+// kvtext returns v as text of a key=value pair, quoted if needed
+func kvtext(v interface{}) string {
+	vs := fmt.Sprint(v)
+	if vs == "" || strings.ContainsAny(vs, " \t\n\"=") {
+		vs = strconv.Quote(vs)
+	}
+	return vs
+}
+
+// eachkv calls f for pairs in b.kv then in more. Stray key is !MISSING.
+func (b *Bld) eachkv(more []interface{}, f func(k string, v interface{})) {
+	for _, kv := range [2][]interface{}{b.kv, more} {
+		for i := 0; i < len(kv); i += 2 {
+			k, ok := kv[i].(string)
+			if !ok {
+				k = fmt.Sprint(kv[i])
+			}
+			if i+1 < len(kv) {
+				f(k, kv[i+1])
+			} else {
+				f(k, "!MISSING")
+			}
+		}
+	}
+}
+
+// record writes a JSONL object, stamped with current time
+func (b *Bld) record(level, msg string, more []interface{}) {
+	b.recordAt(now(), level, msg, more)
+}
+
+// recordAt writes a JSONL object stamped with t; zero t is left out
+func (b *Bld) recordAt(t time.Time, level, msg string, more []interface{}) {
+	msg = strings.TrimRight(msg, "\n")
+	switch {
+	case msg == "" && len(b.kv)+len(more) == 0:
+		return
+	case !b.On():
+		return
+	case b.sbu == nil:
+		b.autonew()
+	}
+	o := []byte{'{'}
+	if !t.IsZero() {
+		o = append(o, `"time":`...)
+		o = strconv.AppendQuote(o, t.Format("2006-01-02T15:04:05.000Z07:00"))
+		o = append(o, ',')
+	}
+	o = append(o, `"level":`...)
+	o = jsonval(o, level)
+	o = append(o, `,"msg":`...)
+	o = jsonval(o, msg)
+	if b.sect != "" {
+		o = append(o, `,"section":`...)
+		o = jsonval(o, b.sect)
+	}
+	b.eachkv(more, func(k string, v interface{}) {
+		o = append(o, ',')
+		o = jsonval(o, k)
+		o = append(o, ':')
+		o = jsonval(o, v)
+	})
+	b.put(string(append(o, '}', '\n')))
+}
+
+// jsonval appends JSON encoded v to o. Errors and Stringers encode as text.
+func jsonval(o []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case json.Marshaler:
+	case error:
+		v = x.Error()
+	case fmt.Stringer:
+		v = x.String()
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		j, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return append(o, j...)
+}
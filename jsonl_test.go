@@ -0,0 +1,66 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"errors"
+	"flag"
+	"testing"
+)
+
+func TestJSONL(t *testing.T) {
+	defer fakeClock(0)()
+	var mo Mode
+	fs := flag.NewFlagSet("t", flag.ContinueOnError)
+	fs.Var(&mo, "o", "output mode")
+	if err := fs.Parse([]string{"-o", "json"}); err != nil || mo != JSONL || mo.String() != "jsonl" {
+		t.Logf("Expected -o json to set JSONL mode, got %v (%v)", mo, err)
+		t.Fail()
+	}
+	if err := mo.Set("yaml"); err == nil {
+		t.Logf("Expected error for unknown mode")
+		t.Fail()
+	}
+	bu := New(1)
+	bu.Mode = mo
+	bu.Printf("started\n")
+	bu.Bar("== Config ")
+	bu.NL()
+	bu.With("file", "a b.conf", "err", errors.New("bad"), "n", 3).Printf("loaded %d", 2)
+	bu.Pif(false, "not printed")
+	bu.KV().Add("k", "v").Print()
+	bu.Bar(40, "Results")
+	bu.Printf("done")
+	exp := `{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"started"}` + "\n" +
+		`{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"loaded 2","section":"Config","file":"a b.conf","err":"bad","n":3}` + "\n" +
+		`{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"","section":"Config","k":"v"}` + "\n" +
+		`{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"done","section":"Results"}` + "\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Mode = Text
+	bu.With("file", "a b.conf", "n").Printf("loaded\n")
+	if exp = "loaded file=\"a b.conf\" n=!MISSING\n"; bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
+
+func TestWithShares(t *testing.T) {
+	bu := New(1)
+	bu.Prefix("> ")
+	for i := 0; i < 5; i++ {
+		bu.With("i", i).Every(2).Printf("tick\n")
+		bu.With("i", i).PrintOnce("k", "once\n")
+	}
+	bu.With("n", 1).Printf("cont ")
+	bu.Printf("end\n")
+	exp := "> tick i=0\n> once i=0\n> tick (suppressed 1 similar) i=2\n" +
+		"> tick (suppressed 1 similar) i=4\n> cont  n=1end\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
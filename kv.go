@@ -0,0 +1,106 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// type KV collects key/value pairs, then prints them as an aligned block:
+//
+//	kv := pb.KV()
+//	kv.Leader = '.'
+//	kv.Add("name", cfg.Name).Add("listen", cfg.Addr).Print()
+//
+//	name ..... myservice
+//	listen ... :8080
+//
+// Lines of multi-line values are indented under the value column.
+type KV struct {
+	Sep    string // between keys and values (def: ": ", or " " with Leader)
+	Leader rune   // if set, it fills gap between key and its value
+	b      *Bld
+	kvs    [][2]string
+	less   func(k1, k2 string) bool
+}
+
+// Method KV returns a KV block builder that prints to b.
+func (b *Bld) KV() *KV { return &KV{b: b} }
+
+// Method Add appends a key with a value formatted as by %v.
+func (kv *KV) Add(key string, val interface{}) *KV {
+	kv.kvs = append(kv.kvs, [2]string{key, fmt.Sprint(val)})
+	return kv
+}
+
+// Method Addf appends a key with a Printf formatted value.
+func (kv *KV) Addf(key, fm string, a ...interface{}) *KV {
+	kv.kvs = append(kv.kvs, [2]string{key, fmt.Sprintf(fm, a...)})
+	return kv
+}
+
+// Method Sort makes Print order pairs by keys using given less func,
+// or alphabetically if less is nil. Pairs print in order added otherwise.
+func (kv *KV) Sort(less func(k1, k2 string) bool) *KV {
+	if less == nil {
+		less = func(k1, k2 string) bool { return k1 < k2 }
+	}
+	kv.less = less
+	return kv
+}
+
+// Method Print writes collected pairs to the Bld, then forgets them.
+func (kv *KV) Print() {
+	defer func() { kv.kvs = kv.kvs[:0] }()
+	if kv.less != nil {
+		sort.SliceStable(kv.kvs, func(i, j int) bool {
+			return kv.less(kv.kvs[i][0], kv.kvs[j][0])
+		})
+	}
+	sep := kv.Sep
+	if sep == "" {
+		sep = ": "
+		if kv.Leader != 0 {
+			sep = " "
+		}
+	}
+	if kv.b.Mode == JSONL {
+		var fs []interface{}
+		for _, p := range kv.kvs {
+			fs = append(fs, p[0], p[1])
+		}
+		kv.b.record("info", "", fs)
+		return
+	}
+	if kv.b.Mode == Markdown {
+		kv.b.mdgap()
+		for _, p := range kv.kvs {
+			kv.b.Printf("- **%s**%s%s\n", p[0], strings.TrimRight(sep, " ")+" ",
+				strings.Replace(p[1], "\n", "<br>", -1))
+		}
+		return
+	}
+	kw := 0
+	for _, p := range kv.kvs {
+		if w := dwidth(p[0]); w > kw {
+			kw = w
+		}
+	}
+	for _, p := range kv.kvs {
+		k := p[0]
+		head := k + strings.Repeat(" ", kw-dwidth(k))
+		if kv.Leader != 0 {
+			head = k + " " + strings.Repeat(string(kv.Leader), kw-dwidth(k)+3)
+		}
+		head += sep
+		indent := strings.Repeat(" ", dwidth(head))
+		for j, v := range strings.Split(p[1], "\n") {
+			if j > 0 {
+				head = indent
+			}
+			kv.b.Printf("%s%s\n", head, v)
+		}
+	}
+}
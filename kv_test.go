@@ -0,0 +1,30 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestKV(t *testing.T) {
+	bu := New(1)
+	kv := bu.KV()
+	kv.Add("name", "cout").Addf("size", "%d B", 256).Add("note", "two\nlines")
+	kv.Print()
+	exp := "name: cout\nsize: 256 B\nnote: two\n      lines\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Prefix("# ")
+	kv.Leader = '.'
+	kv.Add("listen", ":8080").Add("name", "srv").Add("zażółć", 1).Sort(nil).Print()
+	exp = "# listen ... :8080\n# name ..... srv\n# zażółć ... 1\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+	if w := dwidth("\x1b[1m日本\x1b[0m é"); w != 6 {
+		t.Logf("Expected display width 6, but got %d", w)
+		t.Fail()
+	}
+}
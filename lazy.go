@@ -0,0 +1,61 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+// type Lazy is a closure producing text only when (and if) it is printed.
+// Lazy implements fmt.Stringer, so it can be passed to any printer:
+//
+//	pb.V(2).Printf("state: %s\n", cout.Lazy(func() string { return dump(st) }))
+type Lazy func() string
+
+// Method String calls the closure.
+func (f Lazy) String() string { return f() }
+
+// Lazy printer variants take arguments from a closure, called only when
+// there is something to print. Eg. usage:
+//
+//	pb.PifL(err != nil, "%v\n%s\n", func() []interface{} {
+//		return []interface{}{err, expensiveDump()}
+//	})
+
+// Method PrintfL is Printf with arguments returned by f.
+func (b *Bld) PrintfL(fm string, f func() []interface{}) {
+	if !b.On() {
+		return
+	}
+	b.Printf(fm, f()...)
+}
+
+// Method PifL is Pif with arguments returned by f, called only if c is true.
+func (b *Bld) PifL(c bool, fm string, f func() []interface{}) bool {
+	if c {
+		b.PrintfL(fm, f)
+	}
+	return c
+}
+
+// Method PifNotL is PifNot with arguments returned by f, called only if c
+// is false.
+func (b *Bld) PifNotL(c bool, fm string, f func() []interface{}) bool {
+	if !c {
+		b.PrintfL(fm, f)
+	}
+	return c
+}
+
+// Method PrintfL is Gate's Printf with arguments returned by f, called
+// only if the gate is open.
+func (g Gate) PrintfL(fm string, f func() []interface{}) {
+	if g.b != nil && g.b.On() {
+		g.emit(fm, f())
+	}
+}
+
+// Method PifL is Gate's Pif with arguments returned by f, called only if
+// the gate is open and c is true.
+func (g Gate) PifL(c bool, fm string, f func() []interface{}) bool {
+	if c {
+		g.PrintfL(fm, f)
+	}
+	return c
+}
@@ -0,0 +1,33 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	args := func() []interface{} { calls++; return []interface{}{calls} }
+	dump := Lazy(func() string { calls++; return strings.Repeat("#", 3) })
+	bu := New(1)
+	bu.PifL(false, "no %d\n", args)
+	bu.PifNotL(true, "no %d\n", args)
+	bu.V(1).PrintfL("no %d\n", args)
+	bu.V(1).PifL(true, "no %d\n", args)
+	bu.V(1).Printf("no %s\n", dump)
+	if calls != 0 || bu.Len() != 0 {
+		t.Logf("Expected no closure calls nor output, got %d calls, %q", calls, bu.String())
+		t.Fail()
+	}
+	bu.PifL(true, "yes %d\n", args)
+	bu.PifNotL(false, "yes %d\n", args)
+	bu.PrintfL("yes %d\n", args)
+	bu.V(0).PifL(true, "yes %d\n", args)
+	bu.Printf("yes %s\n", dump)
+	if exp := "yes 1\nyes 2\nyes 3\nyes 4\nyes ###\n"; bu.String() != exp || calls != 5 {
+		t.Logf("Expected %q, but got %q (%d calls)", exp, bu.String(), calls)
+		t.Fail()
+	}
+}
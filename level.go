@@ -0,0 +1,153 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// type Verbosity is a threshold of Bld leveled printers: a message prints
+// if its level is not above the Bld's Verb. Verbosity implements flag.Value
+// so that each -v increments it, and -v=N sets it:
+//
+//	flag.Var(&pb.Verb, "v", "be more verbose")
+//	flag.Var(cout.Quiet(&pb.Verb), "q", "be quiet")
+type Verbosity int
+
+// Message levels, from the most important
+const (
+	LvError Verbosity = -2 // Errorf; printed unless -q -q -q
+	LvWarn  Verbosity = -1 // Warnf; printed unless -q -q
+	LvInfo  Verbosity = 0  // Infof; printed by default
+	LvDebug Verbosity = 1  // Debugf, V(1); printed with -v
+)
+
+// Method String returns verbosity as a number.
+func (v *Verbosity) String() string {
+	if v == nil {
+		return "0"
+	}
+	return strconv.Itoa(int(*v))
+}
+
+// Method Set increments verbosity if s is "true", or sets it to number s.
+func (v *Verbosity) Set(s string) error {
+	if s == "true" {
+		*v++
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("cout: bad verbosity %q", s)
+	}
+	*v = Verbosity(n)
+	return nil
+}
+
+// Method IsBoolFlag lets -v be given without a value.
+func (v *Verbosity) IsBoolFlag() bool { return true }
+
+// Method Env sets verbosity from the environment variable name, if it is
+// set: to a number, or to "v", "vv"... (increments), or "q", "qq"...
+// (decrements). It returns true if verbosity was set.
+func (v *Verbosity) Env(name string) bool {
+	s := os.Getenv(name)
+	switch {
+	case s == "":
+		return false
+	case strings.Trim(s, "v") == "":
+		*v = Verbosity(len(s))
+	case strings.Trim(s, "q") == "":
+		*v = -Verbosity(len(s))
+	default:
+		return v.Set(s) == nil
+	}
+	return true
+}
+
+// func Quiet returns flag.Value that decrements v on each -q.
+func Quiet(v *Verbosity) flag.Value { return quiet{v} }
+
+type quiet struct{ v *Verbosity }
+
+func (q quiet) String() string   { return "" }
+func (q quiet) IsBoolFlag() bool { return true }
+func (q quiet) Set(s string) error {
+	if on, err := strconv.ParseBool(s); err != nil || !on {
+		return err
+	}
+	*q.v--
+	return nil
+}
+
+// type Gate is returned by V, Every, and Throttle: its printers print only
+// if the gate is open. Formatting work is done only for open gates.
+// Zero Gate is closed.
+type Gate struct {
+	b    *Bld
+	lv   string // level name in JSONL records
+	note string // appended to the message, eg. "(suppressed 3 similar)"
+}
+
+// Method V returns a Gate that is open if b.Verb is n or more. Eg. usage:
+// `pb.V(2).Printf("%d entries in cache\n", len(cache))`
+func (b *Bld) V(n int) Gate {
+	if Verbosity(n) > b.Verb {
+		return Gate{}
+	}
+	return Gate{b: b, lv: lvname(Verbosity(n))}
+}
+
+// Method On returns true if the gate is open.
+func (g Gate) On() bool { return g.b != nil }
+
+// Method Printf prints as Bld Printf does, if the gate is open.
+func (g Gate) Printf(fm string, a ...interface{}) {
+	if g.b != nil {
+		g.emit(fm, a)
+	}
+}
+
+// Method Pif prints as Bld Pif does, if the gate is open. Returns c.
+func (g Gate) Pif(c bool, fm string, a ...interface{}) bool {
+	if c && g.b != nil {
+		g.emit(fm, a)
+	}
+	return c
+}
+
+// Method Debugf prints if b.Verb is LvDebug or more.
+func (b *Bld) Debugf(fm string, a ...interface{}) { b.V(int(LvDebug)).Printf(fm, a...) }
+
+// Method Infof prints if b.Verb is LvInfo or more.
+func (b *Bld) Infof(fm string, a ...interface{}) { b.V(int(LvInfo)).Printf(fm, a...) }
+
+// Method Warnf prints if b.Verb is LvWarn or more.
+func (b *Bld) Warnf(fm string, a ...interface{}) { b.V(int(LvWarn)).Printf(fm, a...) }
+
+// Method Errorf prints if b.Verb is LvError or more.
+func (b *Bld) Errorf(fm string, a ...interface{}) { b.V(int(LvError)).Printf(fm, a...) }
+
+// printl prints as Printf, but JSONL records get the level
+func (b *Bld) printl(level, fm string, a ...interface{}) {
+	if len(fm) == 0 || !b.On() {
+		return
+	}
+	b.print(level, fm, fmt.Sprintf(fm, a...))
+}
+
+func lvname(v Verbosity) string {
+	switch {
+	case v <= LvError:
+		return "error"
+	case v == LvWarn:
+		return "warn"
+	case v == LvInfo:
+		return "info"
+	}
+	return "debug"
+}
@@ -0,0 +1,47 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+func TestVerbosity(t *testing.T) {
+	bu := New(1)
+	fs := flag.NewFlagSet("t", flag.ContinueOnError)
+	fs.Var(&bu.Verb, "v", "verbose")
+	fs.Var(Quiet(&bu.Verb), "q", "quiet")
+	if err := fs.Parse([]string{"-v", "-v", "-v", "-q"}); err != nil || bu.Verb != 2 {
+		t.Logf("Expected -v -v -v -q to give verbosity 2, got %d (%v)", bu.Verb, err)
+		t.Fail()
+	}
+	bu.V(3).Printf("v3\n")
+	bu.V(2).Printf("v2\n")
+	bu.Debugf("debug\n")
+	bu.Verb = LvWarn
+	bu.Infof("info\n")
+	bu.Warnf("warn\n")
+	bu.Errorf("error\n")
+	bu.V(-1).Pif(true, "v-1\n")
+	if exp := "v2\ndebug\nwarn\nerror\nv-1\n"; bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+	os.Setenv("COUT_TEST_V", "vv")
+	defer os.Unsetenv("COUT_TEST_V")
+	if !bu.Verb.Env("COUT_TEST_V") || bu.Verb != 2 || bu.Verb.Env("COUT_TEST_NOT_SET") {
+		t.Logf("Expected verbosity 2 from env, got %d", bu.Verb)
+		t.Fail()
+	}
+	defer fakeClock(0)()
+	bu.Clear()
+	bu.Mode = JSONL
+	bu.Warnf("careful")
+	exp := `{"time":"2021-11-05T12:00:00.000Z","level":"warn","msg":"careful"}` + "\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
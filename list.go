@@ -0,0 +1,175 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// type ListStyle tells how List items are marked.
+type ListStyle int
+
+// List styles
+const (
+	Bullets ListStyle = iota // - item  (* and + for nested levels)
+	Numbers                  // 1. item
+	Letters                  // a) item
+	Romans                   // i. item
+	Checks                   // [x] item
+)
+
+// type List collects items, possibly nested, then prints them. Items are
+// numbered (or lettered) at Print time, so lists renumber themselves.
+// Wrapped and multi-line items hang under the first line's text:
+//
+//	ls := pb.List(cout.Numbers)
+//	ls.Item("first").Item("second, with subitems")
+//	ls.Sub(cout.Checks).Todo(true, "done").Todo(false, "to do")
+//	ls.Item("third").Print()
+//
+//	1. first
+//	2. second, with subitems
+//	   [x] done
+//	   [ ] to do
+//	3. third
+type List struct {
+	Width int // wrap items to fit that many columns, if set
+	Start int // first number (def: 1)
+	b     *Bld
+	style ListStyle
+	items []litem
+}
+
+type litem struct {
+	text string
+	tick byte // 'x' done, ' ' to do, 0 not a checklist item
+	sub  *List
+}
+
+// Method List returns a list builder of given style that prints to b.
+func (b *Bld) List(style ListStyle) *List { return &List{b: b, style: style} }
+
+// Method Item appends an item formatted as by Printf.
+func (l *List) Item(fm string, a ...interface{}) *List {
+	l.items = append(l.items, litem{text: fmt.Sprintf(fm, a...)})
+	return l
+}
+
+// Method Todo appends a checklist item marked [x] if done, or [ ] if not.
+func (l *List) Todo(done bool, fm string, a ...interface{}) *List {
+	it := litem{text: fmt.Sprintf(fm, a...), tick: ' '}
+	if done {
+		it.tick = 'x'
+	}
+	l.items = append(l.items, it)
+	return l
+}
+
+// Method Sub returns a new list nested under the last item.
+func (l *List) Sub(style ListStyle) *List {
+	if len(l.items) == 0 {
+		l.items = append(l.items, litem{})
+	}
+	sub := &List{b: l.b, style: style}
+	l.items[len(l.items)-1].sub = sub
+	return sub
+}
+
+// Method Print writes the list to the Bld, then forgets its items.
+func (l *List) Print() {
+	if l.b.Mode == Markdown {
+		l.b.mdgap()
+	}
+	l.print(0, 0, l.Width)
+	l.items = l.items[:0]
+}
+
+func (l *List) print(level, depth, width int) {
+	marks := make([]string, len(l.items))
+	mw := 0
+	for i := range l.items {
+		if marks[i] = l.mark(i, level); len(marks[i]) > mw {
+			mw = len(marks[i])
+		}
+	}
+	indent := strings.Repeat(" ", depth)
+	hang := indent + strings.Repeat(" ", mw+1)
+	for i, it := range l.items {
+		if it.text != "" || it.sub == nil {
+			lead := indent + strings.Repeat(" ", mw-len(marks[i])) + marks[i] + " "
+			if it.tick != 0 && l.style != Checks {
+				lead += "[" + string(it.tick) + "] "
+			}
+			tw := 0
+			if width > 0 {
+				if tw = width - len(lead); tw < 1 {
+					tw = 1
+				}
+			}
+			for j, ln := range wrap(it.text, tw) {
+				if j > 0 {
+					lead = hang
+				}
+				l.b.Printf("%s%s\n", lead, ln)
+			}
+		}
+		if it.sub != nil {
+			it.sub.print(level+1, len(hang), width)
+			it.sub.items = it.sub.items[:0]
+		}
+	}
+}
+
+// mark returns marker of i-th item
+func (l *List) mark(i, level int) string {
+	n := i + l.Start
+	if l.Start == 0 {
+		n++
+	}
+	if l.b.Mode == Markdown {
+		switch l.style {
+		case Bullets:
+			return "-"
+		case Checks:
+			if l.items[i].tick == 'x' {
+				return "- [x]"
+			}
+			return "- [ ]"
+		}
+		return strconv.Itoa(n) + "."
+	}
+	switch l.style {
+	case Numbers:
+		return strconv.Itoa(n) + "."
+	case Letters:
+		return letters(n) + ")"
+	case Romans:
+		return romans(n) + "."
+	case Checks:
+		if l.items[i].tick == 'x' {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	return [...]string{"-", "*", "+"}[level%3]
+}
+
+// letters returns a, b, ... z, aa, ab, ...
+func letters(n int) (s string) {
+	for ; n > 0; n = (n - 1) / 26 {
+		s = string(rune('a'+(n-1)%26)) + s
+	}
+	return s
+}
+
+// romans returns lower case roman numeral of n
+func romans(n int) (s string) {
+	for i, v := range [...]int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1} {
+		for ; n >= v; n -= v {
+			s += [...]string{"m", "cm", "d", "cd", "c", "xc", "l", "xl", "x", "ix", "v", "iv", "i"}[i]
+		}
+	}
+	return s
+}
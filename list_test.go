@@ -0,0 +1,38 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestList(t *testing.T) {
+	bu := New(1)
+	ls := bu.List(Numbers)
+	ls.Item("first").Item("second, with subitems")
+	ls.Sub(Checks).Todo(true, "done").Todo(false, "to do")
+	ls.Item("third")
+	for i := 4; i <= 10; i++ {
+		ls.Item("item %d", i)
+	}
+	ls.Print()
+	exp := " 1. first\n 2. second, with subitems\n    [x] done\n    [ ] to do\n 3. third\n" +
+		" 4. item 4\n 5. item 5\n 6. item 6\n 7. item 7\n 8. item 8\n 9. item 9\n10. item 10\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Prefix("> ")
+	ls = bu.List(Bullets)
+	ls.Width = 16
+	ls.Item("a bullet item long enough to wrap")
+	ls.Sub(Romans).Item("one").Item("two").Item("three\nlines").
+		Sub(Letters).Item("x").Item("y").Sub(Bullets).Todo(true, "deep")
+	ls.Item("last").Print()
+	exp = "> - a bullet item\n>   long enough to\n>   wrap\n" +
+		">     i. one\n>    ii. two\n>   iii. three\n>        lines\n" +
+		">        a) x\n>        b) y\n>           - [x] deep\n> - last\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+}
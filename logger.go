@@ -0,0 +1,27 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"io"
+	"log"
+)
+
+// Method Writer returns an io.Writer that prints to b the way Printf does:
+// with prefix, AutoNL, and Mode applied to each Write. Use it to route
+// other packages' output through a Bld.
+func (b *Bld) Writer() io.Writer { return bldw{b} }
+
+// Method Logger returns a standard *log.Logger that prints to b.
+// See log.New for prefix and flag parameters.
+func (b *Bld) Logger(prefix string, flag int) *log.Logger {
+	return log.New(b.Writer(), prefix, flag)
+}
+
+type bldw struct{ b *Bld }
+
+func (w bldw) Write(p []byte) (int, error) {
+	s := string(p)
+	w.b.print("info", s, s)
+	return len(p), nil
+}
@@ -0,0 +1,20 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestLogger(t *testing.T) {
+	bu := New(1)
+	bu.Prefix("app: ")
+	lg := bu.Logger("[db] ", 0)
+	lg.Printf("100%% done")
+	lg.Print("no newline at end")
+	bu.AutoNL = true
+	bu.Writer().Write([]byte("raw %d write"))
+	exp := "app: [db] 100% done\napp: [db] no newline at end\napp: raw %d write\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,63 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "strings"
+
+// In Markdown mode a titled Bar renders as a heading, its level taken from
+// the bar character: '=' gives "#", '-' gives "##", and any other "###".
+// Bar without a title renders as a "---" rule. Lists render as Markdown
+// lists, KV as a list of bold keys, Table as a pipe table, Box and Columns
+// as fenced blocks.
+
+// mdbar writes a heading for titled bar, or a rule
+func (b *Bld) mdbar(bstr string) {
+	fill, title := bstr[:1], bartitle(bstr)
+	b.mdgap()
+	switch {
+	case title == "":
+		b.Printf("---\n\n")
+	case fill == "=":
+		b.Printf("# %s\n\n", title)
+	case fill == "-":
+		b.Printf("## %s\n\n", title)
+	default:
+		b.Printf("### %s\n\n", title)
+	}
+}
+
+// bartitle returns title of a Bar, without runs of the fill character that
+// are set apart from it by a space. Eg. "== Report ==" gives "Report", but
+// "Results" (that fills with 'R') stays as is.
+func bartitle(bstr string) string {
+	fill := bstr[:1]
+	if t := strings.TrimLeft(bstr, fill); t == "" || t[0] == ' ' {
+		bstr = t
+	}
+	if t := strings.TrimRight(bstr, fill); t != bstr && strings.HasSuffix(t, " ") {
+		bstr = t
+	}
+	return strings.TrimSpace(bstr)
+}
+
+// mdgap makes sure a block will be separated by an empty line
+func (b *Bld) mdgap() {
+	if b.sbu != nil && b.to == b.sbu && b.Len() > 0 {
+		b.ENL()
+	}
+}
+
+// fence writes lines as a fenced code block
+func (b *Bld) fence(lines []string) {
+	fc := "```"
+	for _, ln := range lines {
+		for strings.Contains(ln, fc) {
+			fc += "`"
+		}
+	}
+	b.Printf("%s\n", fc)
+	for _, ln := range lines {
+		b.Printf("%s\n", ln)
+	}
+	b.Printf("%s\n\n", fc)
+}
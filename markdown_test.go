@@ -0,0 +1,32 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestMarkdown(t *testing.T) {
+	bu := New(1)
+	bu.Mode = Markdown
+	bu.Printf("Intro text.\n")
+	bu.Bar(40, "== Report ")
+	bu.Bar("-- Details ")
+	bu.Bar(40, "Results")
+	ls := bu.List(Letters)
+	ls.Item("one").Sub(Checks).Todo(true, "done").Todo(false, "not yet")
+	ls.Item("two").Print()
+	bu.KV().Add("name", "cout").Add("lines", "a\nb").Print()
+	bu.Bar()
+	bu.Box("Note", "``` inside\nbox")
+	col := New(1)
+	col.Printf("a\nb\n")
+	bu.Columns(1, &col, &col)
+	exp := "Intro text.\n\n# Report\n\n## Details\n\n### Results\n\n" +
+		"1. one\n   - [x] done\n   - [ ] not yet\n2. two\n\n" +
+		"- **name**: cout\n- **lines**: a<br>b\n\n---\n\n" +
+		"**Note**\n````\n``` inside\nbox\n````\n\n" +
+		"```\na a\nb b\n```\n\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+}
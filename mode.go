@@ -0,0 +1,50 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// type Mode selects how Bld printers render their output. Mode implements
+// flag.Value, so it can be chosen at runtime without changing call sites:
+// `flag.Var(&pb.Mode, "o", "output: text, md, jsonl, csv, or tsv")`
+type Mode int
+
+// Rendering modes
+const (
+	Text     Mode = iota // plain text, for terminals
+	Markdown             // GitHub flavored Markdown, for PRs and wikis
+	JSONL                // JSON Lines, a record per printer call
+	CSV                  // RFC 4180 CSV Tables, other printers as Text
+	TSV                  // tab separated Tables, other printers as Text
+)
+
+var modes = [...]string{Text: "text", Markdown: "md", JSONL: "jsonl", CSV: "csv", TSV: "tsv"}
+
+// Method String returns mode name, as accepted by Set.
+func (m Mode) String() string {
+	if m >= 0 && int(m) < len(modes) {
+		return modes[m]
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Method Set sets mode by its name: text, md (markdown), jsonl (json),
+// csv, or tsv.
+func (m *Mode) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "markdown":
+		s = "md"
+	case "json":
+		s = "jsonl"
+	}
+	for i, n := range modes {
+		if n == strings.ToLower(s) {
+			*m = Mode(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("cout: unknown output mode %q", s)
+}
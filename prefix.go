@@ -0,0 +1,126 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pseg is a part of prefix template: literal text, or a field to fill
+type pseg struct {
+	lit   string
+	field string
+}
+
+var started = time.Now() // for {elapsed}
+
+// Method PrefixTpl sets a prefix template whose {fields} are filled in
+// each time the prefix is written (see Prefix). Known fields are:
+//
+//	{time}     wall clock time: 15:04:05.000
+//	{elapsed}  seconds since program start: 12.345
+//	{gid}      id of the calling goroutine
+//	{file}     caller's file:line
+//	{func}     caller's package.Function
+//
+// Eg. `pb.PrefixTpl("{time} {file}: ")`. Unknown fields print as given.
+// Caller is the first function up the stack that is not in cout package.
+func (b *Bld) PrefixTpl(tpl string) {
+	b.Prefix("")
+	for len(tpl) > 0 {
+		op := strings.IndexByte(tpl, '{')
+		cl := strings.IndexByte(tpl[op+1:], '}') + op + 1
+		if op < 0 || cl <= op {
+			b.ptpl = append(b.ptpl, pseg{lit: tpl})
+			break
+		}
+		if op > 0 {
+			b.ptpl = append(b.ptpl, pseg{lit: tpl[:op]})
+		}
+		b.ptpl = append(b.ptpl, pseg{field: tpl[op+1 : cl]})
+		tpl = tpl[cl+1:]
+	}
+	b.haspfx = len(b.ptpl) > 0 || len(b.ind) > 0
+}
+
+// lead writes the prefix
+func (b *Bld) lead() {
+	if b.ptpl == nil {
+		b.put(string(append(b.pfx[:len(b.pfx):len(b.pfx)], b.ind...)))
+		return
+	}
+	var o []byte
+	var fr *runtime.Frame
+	for _, sg := range b.ptpl {
+		switch sg.field {
+		case "":
+			o = append(o, sg.lit...)
+		case "time":
+			o = now().AppendFormat(o, "15:04:05.000")
+		case "elapsed":
+			o = strconv.AppendFloat(o, now().Sub(started).Seconds(), 'f', 3, 64)
+		case "gid":
+			o = strconv.AppendUint(o, gid(), 10)
+		case "file", "func":
+			if fr == nil {
+				fr = callsite()
+			}
+			if sg.field == "file" {
+				o = append(o, filepath.Base(fr.File)+":"+strconv.Itoa(fr.Line)...)
+			} else {
+				o = append(o, shortfunc(fr.Function)...)
+			}
+		default:
+			o = append(o, '{')
+			o = append(o, sg.field...)
+			o = append(o, '}')
+		}
+	}
+	b.put(string(append(o, b.ind...)))
+}
+
+// pkgdir is where cout sources are, to skip own frames in callsite
+var pkgdir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callsite returns frame of the first caller outside of cout package
+// (and of log packages, that print through cout Writer).
+func callsite() *runtime.Frame {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		fr, more := frames.Next()
+		own := filepath.Dir(fr.File) == pkgdir && !strings.HasSuffix(fr.File, "_test.go")
+		logs := strings.HasPrefix(fr.Function, "log.") || strings.HasPrefix(fr.Function, "log/slog.")
+		if !more || !own && !logs {
+			return &fr
+		}
+	}
+}
+
+// shortfunc strips import path from function name: pkg.Func
+func shortfunc(fn string) string {
+	if sl := strings.LastIndexByte(fn, '/'); sl >= 0 {
+		fn = fn[sl+1:]
+	}
+	return fn
+}
+
+// gid returns id of the current goroutine, as seen in stack traces
+func gid() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if sp := bytes.IndexByte(buf, ' '); sp > 0 {
+		buf = buf[:sp]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,37 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestPrefixTpl(t *testing.T) {
+	defer fakeClock(0)()
+	bu := New(1)
+	bu.PrefixTpl("{time} {file} {func} g{gid} {what} ")
+	bu.Printf("first\n")
+	bu.Pif(true, "second\n")
+	bu.V(0).Printf("third\n")
+	bu.Logger("", 0).Print("fourth")
+	got := bu.String()
+	re := regexp.MustCompile(`^(12:00:00\.000 prefix_test\.go:\d+ cout\.TestPrefixTpl g\d+ \{what\} (first|second|third|fourth)\n){4}$`)
+	if !re.MatchString(got) {
+		t.Logf("Unexpected prefixed output:\n%s", got)
+		t.Fail()
+	}
+	bu.Clear()
+	defer func(t0 time.Time) { started = t0 }(started)
+	started = now().Add(-1500 * time.Millisecond)
+	bu.PrefixTpl("[{elapsed}] ")
+	bu.Printf("go")
+	bu.Prefix("static ")
+	bu.NL()
+	bu.Printf("stop\n")
+	if exp := "[1.500] go\nstatic stop\n"; bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
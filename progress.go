@@ -0,0 +1,161 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// type Progress counts bytes going through a wrapped io.Reader or io.Writer
+// and reports them on a Bld as a progress bar: bytes done (of total), rate
+// and ETA. Zero buffers redraw a single line in place (with \r) at most
+// once per Every; buffered Blds, and Blds in JSONL mode, get only the final
+// summary line.
+type Progress struct {
+	Total int64         // expected size; 0 if unknown
+	N     int64         // bytes seen so far
+	Every time.Duration // redraw interval (def: 200ms)
+	r     io.Reader
+	w     io.Writer
+	b     *Bld
+	t0    time.Time // first byte seen
+	last  time.Time // last redraw
+	wide  int       // length of last drawn line
+	done  bool
+}
+
+// time source, replaced in tests
+var now = time.Now
+
+// func ProgressReader wraps r so that io.Copy (or any other consumer)
+// drives a progress bar printed on bar. Total is the expected size, or 0
+// if not known. Final line is printed on io.EOF, or by an explicit Done.
+func ProgressReader(r io.Reader, total int64, bar *Bld) *Progress {
+	return &Progress{r: r, Total: total, b: bar}
+}
+
+// func ProgressWriter wraps w, otherwise it works as ProgressReader does.
+// Final line is printed when Total bytes were written, or by Done.
+func ProgressWriter(w io.Writer, total int64, bar *Bld) *Progress {
+	return &Progress{w: w, Total: total, b: bar}
+}
+
+// Method Read reads from the wrapped io.Reader.
+func (p *Progress) Read(buf []byte) (n int, err error) {
+	n, err = p.r.Read(buf)
+	p.add(n)
+	if err == io.EOF {
+		p.Done()
+	}
+	return n, err
+}
+
+// Method Write writes to the wrapped io.Writer.
+func (p *Progress) Write(buf []byte) (n int, err error) {
+	n, err = p.w.Write(buf)
+	p.add(n)
+	if p.Total > 0 && p.N >= p.Total {
+		p.Done()
+	}
+	return n, err
+}
+
+// Method Done prints the final summary line. Next calls are no-op.
+func (p *Progress) Done() {
+	if p.done {
+		return
+	}
+	p.done = true
+	if p.t0.IsZero() {
+		p.t0 = now()
+	}
+	took := now().Sub(p.t0)
+	ln := fmt.Sprintf("%s%s in %s (%s/s)",
+		p.gauge(), hbytes(p.N, false, 1), hdur(took), hbytes(rate(p.N, took), false, 1))
+	if p.b.sbu == nil {
+		p.b.autonew()
+	}
+	if p.b.to != p.b.sbu && p.b.Mode != JSONL {
+		p.draw(ln)
+		p.b.put("\n")
+		p.b.skipfx = false
+		return
+	}
+	p.b.Printf("%s\n", ln)
+}
+
+func (p *Progress) add(n int) {
+	t := now()
+	if p.t0.IsZero() {
+		p.t0 = t
+	}
+	p.N += int64(n)
+	every := p.Every
+	if every == 0 {
+		every = 200 * time.Millisecond
+	}
+	if p.done || t.Sub(p.last) < every {
+		return
+	}
+	p.last = t
+	if p.b.sbu == nil {
+		p.b.autonew()
+	}
+	if p.b.to == p.b.sbu || p.b.Mode == JSONL { // no redraws
+		return
+	}
+	took := t.Sub(p.t0)
+	bps := rate(p.N, took)
+	ln := p.gauge() + hbytes(p.N, false, 1)
+	if p.Total > 0 {
+		ln += " / " + hbytes(p.Total, false, 1)
+	}
+	ln += "  " + hbytes(bps, false, 1) + "/s"
+	if p.Total > p.N && bps > 0 {
+		ln += "  ETA " + hdur(time.Duration(float64(p.Total-p.N)/float64(bps)*float64(time.Second)))
+	}
+	p.draw(ln)
+}
+
+// draw rewrites current terminal line, wiping leftovers of previous one
+func (p *Progress) draw(ln string) {
+	pad := p.wide - len(ln)
+	p.wide = len(ln)
+	if pad < 0 {
+		pad = 0
+	}
+	p.b.put("\r")
+	if p.b.haspfx {
+		p.b.lead()
+	}
+	p.b.put(ln + strings.Repeat(" ", pad))
+}
+
+// gauge returns "[=====>    ]  45%  " part, if Total is known
+func (p *Progress) gauge() string {
+	const width = 20
+	if p.Total <= 0 {
+		return ""
+	}
+	pc := int(p.N * 100 / p.Total)
+	if pc > 100 {
+		pc = 100
+	}
+	fill := pc * width / 100
+	g := strings.Repeat("=", fill)
+	if fill < width {
+		g += ">" + strings.Repeat(" ", width-fill-1)
+	}
+	return fmt.Sprintf("[%s] %3d%%  ", g, pc)
+}
+
+// rate returns bytes per second
+func rate(n int64, took time.Duration) int64 {
+	if took < time.Millisecond {
+		return n
+	}
+	return int64(float64(n) / took.Seconds())
+}
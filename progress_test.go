@@ -0,0 +1,101 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fakeClock(step time.Duration) func() {
+	t := time.Date(2021, 11, 5, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { t = t.Add(step); return t }
+	return func() { now = time.Now }
+}
+
+func TestProgress(t *testing.T) {
+	defer fakeClock(time.Second)()
+	bu := New(1)
+	pr := ProgressReader(strings.NewReader(strings.Repeat("x", 2048)), 2048, &bu)
+	if n, err := io.Copy(ioutil.Discard, pr); n != 2048 || err != nil {
+		t.Logf("Copy through ProgressReader: %d bytes, err %v", n, err)
+		t.Fail()
+	}
+	exp := "[====================] 100%  2.0 KiB in 2s (1.0 KiB/s)\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	var sink strings.Builder
+	pw := ProgressWriter(&sink, 0, &bu)
+	io.WriteString(pw, strings.Repeat("y", 3000))
+	pw.Done()
+	pw.Done()
+	exp = "2.9 KiB in 1s (2.9 KiB/s)\n"
+	if bu.String() != exp || sink.Len() != 3000 {
+		t.Logf("Expected %q, but got %q (%d)", exp, bu.String(), sink.Len())
+		t.Fail()
+	}
+}
+
+func TestProgressJSONL(t *testing.T) {
+	defer fakeClock(time.Second)()
+	sink := New(1)
+	defer func() { Capture = nil }()
+	Capture = &sink
+	zb := New(0)
+	zb.Mode = JSONL
+	pw := ProgressWriter(ioutil.Discard, 4, &zb)
+	io.WriteString(pw, "da")
+	io.WriteString(pw, "ta")
+	exp := `{"time":"2021-11-05T12:00:04.000Z","level":"info","msg":"[====================] 100%  4 B in 2s (2 B/s)"}` + "\n"
+	if sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+}
+
+func TestHumanUnits(t *testing.T) {
+	for _, c := range []struct {
+		n   int64
+		si  bool
+		exp string
+	}{
+		{0, false, "0 B"},
+		{1023, false, "1023 B"},
+		{1024, false, "1.0 KiB"},
+		{1 << 20 * 3 / 2, false, "1.5 MiB"},
+		{1500, true, "1.5 kB"},
+		{-2048, false, "-2.0 KiB"},
+	} {
+		if got := hbytes(c.n, c.si, 1); got != c.exp {
+			t.Logf("hbytes(%d, %v): expected %q, got %q", c.n, c.si, c.exp, got)
+			t.Fail()
+		}
+	}
+	if got := hdur(3*time.Minute + 12*time.Second + 300*time.Millisecond); got != "3m12s" {
+		t.Logf("hdur: expected 3m12s, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestProgressPrefix(t *testing.T) {
+	defer fakeClock(time.Second)()
+	sink := New(1)
+	defer func() { Capture = nil }()
+	Capture = &sink
+	zb := New(0)
+	zb.PrefixTpl("{time} ")
+	pw := ProgressWriter(ioutil.Discard, 0, &zb)
+	io.WriteString(pw, "data")
+	pw.Done()
+	exp := "\r12:00:02.000 4 B  4 B/s\r12:00:04.000 4 B in 2s (2 B/s)\n"
+	if sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,137 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ring keeps limits and state of Ring and Truncate buffers
+type ring struct {
+	head         int    // lines kept at the start
+	lines, bytes int    // limits of the retained tail; 0 is no limit, -1 none
+	gone         int    // lines dropped so far
+	mark         string // elision marker, after the head
+	next         int    // buffer length that triggers next trim
+}
+
+// Method Ring makes b keep only the last lines of its content, and at most
+// bytes of it. Zero means no limit. Buffer content (Trimmed, Out) then starts
+// with a "... 123 lines omitted ...\n" line, if anything was dropped.
+// Ring(0, 0) turns the limits off. Eg. for a daemon, dumped on failure:
+//
+//	diag := cout.New(1)
+//	diag.Ring(500, 0)
+//	...
+//	diag.Out() // last 500 lines
+func (b *Bld) Ring(lines, bytes int) {
+	if b.sbu == nil {
+		b.autonew()
+	}
+	if lines <= 0 && bytes <= 0 {
+		b.ring = nil
+		return
+	}
+	b.ring = &ring{lines: lines, bytes: bytes}
+	b.trim()
+}
+
+// Method Trimmed returns buffer content with Ring or Truncate limits
+// applied. Printers apply them in batches, as content grows, so String
+// may return up to twice as much.
+func (b *Bld) Trimmed() string {
+	if b.sbu == nil {
+		return ""
+	}
+	if b.ring != nil {
+		b.trim()
+	}
+	return b.String()
+}
+
+// Method Truncate makes b keep only the first head and the last tail lines
+// of its content, if there is more of it. Lines in between are replaced
+// with a "... 10234 lines omitted ...\n" line. Truncate(0, 0) turns it off.
+func (b *Bld) Truncate(head, tail int) {
+	if b.sbu == nil {
+		b.autonew()
+	}
+	if head <= 0 && tail <= 0 {
+		b.ring = nil
+		return
+	}
+	if tail <= 0 {
+		tail = -1
+	}
+	b.ring = &ring{head: head, lines: tail}
+	b.trim()
+}
+
+// trim drops content over the ring limits, then updates elision marker
+func (b *Bld) trim() {
+	r := b.ring
+	full, hlen := b.String(), 0
+	if r.head > 0 { // head complete yet?
+		n := 0
+		for i := 0; i < len(full); i++ {
+			if full[i] == '\n' {
+				if n++; n == r.head {
+					hlen = i + 1
+					break
+				}
+			}
+		}
+		if hlen == 0 || hlen == len(full) {
+			r.next = 2 * b.Len()
+			return
+		}
+	}
+	if !strings.HasPrefix(full[hlen:], r.mark) { // was Reset
+		r.gone, r.mark = 0, ""
+	}
+	s := full[hlen+len(r.mark):]
+	cut := 0
+	if r.lines < 0 {
+		cut = len(s)
+	}
+	if r.lines > 0 { // start of the r.lines-th line from the end
+		scan, n := len(s), 0
+		if scan > 0 && s[scan-1] == '\n' {
+			scan--
+		}
+		for i := scan - 1; i >= 0; i-- {
+			if s[i] == '\n' {
+				if n++; n == r.lines {
+					cut = i + 1
+					break
+				}
+			}
+		}
+	}
+	if r.bytes > 0 && len(s)-cut > r.bytes {
+		cut = len(s) - r.bytes
+		if nl := strings.IndexByte(s[cut:], '\n'); nl >= 0 && nl < len(s)-cut-1 {
+			cut += nl + 1 // at line start
+		}
+	}
+	if cut > 0 {
+		r.gone += strings.Count(s[:cut], "\n")
+		if s[cut-1] != '\n' {
+			r.gone++ // partial line
+		}
+		lines := "lines"
+		if r.gone == 1 {
+			lines = "line"
+		}
+		r.mark = fmt.Sprintf("... %d %s omitted ...\n", r.gone, lines)
+		s = full[:hlen] + r.mark + s[cut:]
+		b.Reset()
+		b.Grow(len(s) + b.size)
+		b.sbu.WriteString(s)
+	}
+	r.next = 2 * b.Len()
+	if r.next < MinSize {
+		r.next = MinSize
+	}
+}
@@ -0,0 +1,111 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	bu := New(1)
+	bu.Ring(3, 0)
+	for i := 1; i <= 1000; i++ {
+		bu.Printf("line %d\n", i)
+	}
+	if bu.Len() > 2*MinSize {
+		t.Logf("Expected ring to trim on writes, but buffer has %d bytes", bu.Len())
+		t.Fail()
+	}
+	exp := "... 997 lines omitted ...\nline 998\nline 999\nline 1000\n"
+	if bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+	sink := New(1)
+	bu.SetOut(&sink)
+	bu.Printf("partial")
+	bu.Out()
+	if exp = "... 998 lines omitted ...\nline 999\nline 1000\npartial"; sink.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, sink.String())
+		t.Fail()
+	}
+	bu.Ring(0, 20)
+	bu.Printf("%s\n%s\n", strings.Repeat("a", 15), strings.Repeat("b", 15))
+	if exp = "... 1 line omitted ...\nbbbbbbbbbbbbbbb\n"; bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+	bu.Ring(0, 0)
+	bu.Printf("c\n")
+	if exp += "c\n"; bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	bu := New(1)
+	bu.Truncate(2, 2)
+	bu.Printf("line 1\nline 2\nline 3\n")
+	if exp := "line 1\nline 2\nline 3\n"; bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+	for i := 4; i <= 10240; i++ {
+		bu.Printf("line %d\n", i)
+	}
+	exp := "line 1\nline 2\n... 10236 lines omitted ...\nline 10239\nline 10240\n"
+	if bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Truncate(1, 0)
+	bu.Printf("a\nb\nc\n")
+	if exp = "a\n... 2 lines omitted ...\n"; bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+}
+
+func TestRingReset(t *testing.T) {
+	bu := New(1)
+	bu.Ring(3, 0)
+	for i := 1; i <= 1000; i++ {
+		bu.Printf("line %d\n", i)
+	}
+	bu.Reset()
+	bu.Printf("x\n")
+	if exp := "x\n"; bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+	bu.Truncate(1, 1)
+	bu.Printf("a\nb\nc\n")
+	bu.Reset()
+	bu.Printf("d\ne\nf\n")
+	if exp := "d\n... 1 line omitted ...\nf\n"; bu.Trimmed() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.Trimmed())
+		t.Fail()
+	}
+}
+
+func TestRingBounded(t *testing.T) {
+	defer fakeClock(0)()
+	bu := New(1)
+	bu.Mode = JSONL
+	bu.Ring(3, 0)
+	for i := 1; i <= 10000; i++ {
+		bu.Printf("line %d", i)
+	}
+	if bu.Len() > 4*MinSize {
+		t.Logf("Expected JSONL ring to trim on writes, but buffer has %d bytes", bu.Len())
+		t.Fail()
+	}
+	if s := fmt.Sprint(bu); s != bu.String() {
+		t.Logf("Expected Bld to print its content, but got %q", s)
+		t.Fail()
+	}
+}
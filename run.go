@@ -0,0 +1,111 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// type RunOptions tells Run how to mark lines of child's streams.
+type RunOptions struct {
+	Out   string // stdout line prefix (def: "out| ")
+	Err   string // stderr line prefix (def: "err| ")
+	Color bool   // color prefixes using ANSI escapes: stderr red, stdout dim
+}
+
+// Method Run runs cmd, printing its command line, then its stdout and stderr
+// line by line, each stream with its own prefix (after b's own one), in the
+// order lines came. Closing line tells exit status and how long it ran:
+//
+//	$ make test
+//	out| ok   pkg/a  0.21s
+//	err| FAIL pkg/b
+//	= exit 2 in 1.4s
+//
+// Options, if given, set stream prefixes and colors. In JSONL mode lines
+// go as records with "stream" field. Run sets cmd.Stdout and cmd.Stderr,
+// and returns what cmd.Run returned. Do not use b until Run returns.
+func (b *Bld) Run(cmd *exec.Cmd, opts ...RunOptions) error {
+	var o RunOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Out == "" {
+		o.Out = "out| "
+	}
+	if o.Err == "" {
+		o.Err = "err| "
+	}
+	if o.Color {
+		o.Out = "\x1b[2m" + o.Out + "\x1b[0m"
+		o.Err = "\x1b[31m" + o.Err + "\x1b[0m"
+	}
+	if b.sbu == nil {
+		b.autonew()
+	}
+	mu := new(sync.Mutex)
+	stdout := &runw{b: b, mu: mu, pfx: o.Out, name: "stdout"}
+	stderr := &runw{b: b, mu: mu, pfx: o.Err, name: "stderr"}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	b.Printf("$ %s\n", strings.Join(cmd.Args, " "))
+	t0 := now()
+	err := cmd.Run()
+	took := now().Sub(t0)
+	stdout.flush()
+	stderr.flush()
+	status := "exit 0"
+	var ee *exec.ExitError
+	switch {
+	case errors.As(err, &ee) && ee.ExitCode() >= 0:
+		status = fmt.Sprintf("exit %d", ee.ExitCode())
+	case err != nil:
+		status = err.Error()
+	}
+	b.Printf("= %s in %s\n", status, hdur(took))
+	return err
+}
+
+// runw writes whole lines of a child's stream to Bld
+type runw struct {
+	b    *Bld
+	mu   *sync.Mutex // shared by streams of a child
+	pfx  string
+	name string
+	part []byte // incomplete line
+}
+
+func (w *runw) Write(p []byte) (int, error) {
+	w.part = append(w.part, p...)
+	for {
+		nl := bytes.IndexByte(w.part, '\n')
+		if nl < 0 {
+			return len(p), nil
+		}
+		w.line(string(w.part[:nl]))
+		w.part = w.part[nl+1:]
+	}
+}
+
+// flush writes incomplete last line, if any
+func (w *runw) flush() {
+	if len(w.part) > 0 {
+		w.line(string(w.part))
+		w.part = nil
+	}
+}
+
+func (w *runw) line(ln string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ln = strings.TrimSuffix(ln, "\r")
+	if w.b.Mode == JSONL {
+		w.b.record("info", ln, []interface{}{"stream", w.name})
+		return
+	}
+	w.b.Printf("%s%s\n", w.pfx, ln)
+}
@@ -0,0 +1,34 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh to run")
+	}
+	defer fakeClock(time.Second)()
+	bu := New(1)
+	bu.Prefix("> ")
+	err = bu.Run(exec.Command(sh, "-c", "echo one; sleep 0.1; echo two >&2; sleep 0.1; printf three; exit 3"))
+	exp := "> $ " + sh + " -c echo one; sleep 0.1; echo two >&2; sleep 0.1; printf three; exit 3\n" +
+		"> out| one\n> err| two\n> out| three\n> = exit 3 in 1s\n"
+	if _, ok := err.(*exec.ExitError); !ok || bu.String() != exp {
+		t.Logf("Expected %q, but got %q (err %v)", exp, bu.String(), err)
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Prefix("")
+	err = bu.Run(exec.Command(sh, "-c", "echo hi >&2"), RunOptions{Err: "E ", Color: true})
+	exp = "$ " + sh + " -c echo hi >&2\n\x1b[31mE \x1b[0mhi\n= exit 0 in 1s\n"
+	if err != nil || bu.String() != exp {
+		t.Logf("Expected %q, but got %q (err %v)", exp, bu.String(), err)
+		t.Fail()
+	}
+}
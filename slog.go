@@ -0,0 +1,148 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+//go:build go1.21
+// +build go1.21
+
+package cout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// type SlogOptions configures handler returned by NewSlogHandler.
+type SlogOptions struct {
+	Level    slog.Leveler // lowest level printed (def: slog.LevelInfo)
+	Color    bool         // color level names using ANSI escapes
+	MsgWidth int          // pad messages so keys align (def: 40)
+	NoTime   bool         // do not print record time
+}
+
+// type SlogHandler is a log/slog Handler that prints records to a Bld,
+// in text style: time, level, message, then key=value attributes aligned
+// in a column. If Bld is in JSONL mode, records print as its JSONL objects.
+//
+//	logger := slog.New(cout.NewSlogHandler(&pb, &cout.SlogOptions{Color: true}))
+//	logger.Info("started", "port", 8080)
+//
+//	12:00:00.000 INFO  started                                  port=8080
+type SlogHandler struct {
+	o     SlogOptions
+	b     *Bld
+	mu    *sync.Mutex
+	attrs []interface{} // key, value pairs from WithAttrs
+	group string        // "g1.g2." key prefix from WithGroup
+}
+
+// func NewSlogHandler returns slog.Handler that prints to b. Opts may be nil.
+func NewSlogHandler(b *Bld, opts *SlogOptions) *SlogHandler {
+	h := &SlogHandler{b: b, mu: new(sync.Mutex)}
+	if opts != nil {
+		h.o = *opts
+	}
+	if h.o.Level == nil {
+		h.o.Level = slog.LevelInfo
+	}
+	if h.o.MsgWidth == 0 {
+		h.o.MsgWidth = 40
+	}
+	return h
+}
+
+// Method Enabled reports whether level is printed.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.o.Level.Level()
+}
+
+// Method Handle prints the record.
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	kv := append([]interface{}(nil), h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		kv = appendattr(kv, h.group, a)
+		return true
+	})
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.b.Mode == JSONL {
+		tm := r.Time
+		if h.o.NoTime {
+			tm = time.Time{}
+		}
+		h.b.recordAt(tm, strings.ToLower(r.Level.String()), r.Message, kv)
+		return nil
+	}
+	var o strings.Builder
+	if !h.o.NoTime && !r.Time.IsZero() {
+		o.WriteString(r.Time.Format("15:04:05.000 "))
+	}
+	lv := fmt.Sprintf("%-5s", r.Level.String())
+	if h.o.Color {
+		lv = lvcolor(r.Level) + lv + "\x1b[0m"
+	}
+	o.WriteString(lv + " " + r.Message)
+	if len(kv) > 0 {
+		if pad := h.o.MsgWidth - dwidth(r.Message); pad > 0 {
+			o.WriteString(strings.Repeat(" ", pad))
+		}
+		for i := 0; i < len(kv); i += 2 {
+			o.WriteString(" " + kv[i].(string) + "=" + kvtext(kv[i+1]))
+		}
+	}
+	h.b.Printf("%s\n", o.String())
+	return nil
+}
+
+// Method WithAttrs returns a handler that adds attrs to every record.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = append([]interface{}(nil), h.attrs...)
+	for _, a := range attrs {
+		c.attrs = appendattr(c.attrs, h.group, a)
+	}
+	return &c
+}
+
+// Method WithGroup returns a handler that qualifies next keys with name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.group += name + "."
+	return &c
+}
+
+// appendattr flattens a into key, value pairs; group keys are dotted
+func appendattr(kv []interface{}, group string, a slog.Attr) []interface{} {
+	v := a.Value.Resolve()
+	switch {
+	case a.Equal(slog.Attr{}):
+		return kv
+	case v.Kind() == slog.KindGroup:
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			kv = appendattr(kv, group, ga)
+		}
+		return kv
+	}
+	return append(kv, group+a.Key, v.Any())
+}
+
+// lvcolor returns ANSI color for the level
+func lvcolor(l slog.Level) string {
+	switch {
+	case l >= slog.LevelError:
+		return "\x1b[31m" // red
+	case l >= slog.LevelWarn:
+		return "\x1b[33m" // yellow
+	case l >= slog.LevelInfo:
+		return "\x1b[32m" // green
+	}
+	return "\x1b[36m" // cyan
+}
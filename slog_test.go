@@ -0,0 +1,47 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+//go:build go1.21
+// +build go1.21
+
+package cout
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSlog(t *testing.T) {
+	defer fakeClock(0)()
+	bu := New(1)
+	lg := slog.New(NewSlogHandler(&bu, &SlogOptions{Level: slog.LevelDebug, MsgWidth: 12, NoTime: true}))
+	lg.Debug("dbg")
+	lg.With("svc", "api").WithGroup("req").Info("started", "port", 8080, slog.Group("peer", "ip", "::1"))
+	lg.Warn("odd value", "v", "a b")
+	exp := "DEBUG dbg\n" +
+		"INFO  started      svc=api req.port=8080 req.peer.ip=::1\n" +
+		"WARN  odd value    v=\"a b\"\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	slog.New(NewSlogHandler(&bu, &SlogOptions{Color: true, NoTime: true})).Error("bad")
+	if exp = "\x1b[31mERROR\x1b[0m bad\n"; bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+	bu.Clear()
+	bu.Mode = JSONL
+	r := slog.NewRecord(time.Date(2021, 11, 5, 13, 0, 0, 0, time.UTC), slog.LevelWarn, "careful", 0)
+	r.Add("n", 1)
+	NewSlogHandler(&bu, nil).Handle(context.Background(), r)
+	slog.New(NewSlogHandler(&bu, &SlogOptions{NoTime: true})).Info("untimed")
+	exp = `{"time":"2021-11-05T13:00:00.000Z","level":"warn","msg":"careful","n":1}` + "\n" +
+		`{"level":"info","msg":"untimed"}` + "\n"
+	if bu.String() != exp {
+		t.Logf("Expected %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
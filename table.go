@@ -0,0 +1,179 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// type Col defines a Table column.
+type Col struct {
+	Name  string // header
+	Width int    // truncate longer text cells; 0: no limit
+	Right bool   // align text cells right, eg. for numbers
+}
+
+// type Table prints rows of data under column headers, as set by Mode of
+// its Bld: aligned text, Markdown pipe table, JSONL records (with column
+// names as keys), or CSV/TSV. The same calls render each of these:
+//
+//	tb := pb.Table(cout.Col{Name: "file"}, cout.Col{Name: "size", Right: true})
+//	tb.Row("a.txt", 12).Row("b.txt", 3456).Print()
+//
+//	file   size
+//	-----  ----
+//	a.txt    12
+//	b.txt  3456
+//
+// CSV and TSV rows are written as soon as they are added; other modes
+// print on Print.
+type Table struct {
+	b    *Bld
+	cols []Col
+	rows [][]string
+	vals [][]interface{} // JSONL rows, as given
+	cw   *csv.Writer
+}
+
+// Method Table returns a table of given columns that prints to b.
+func (b *Bld) Table(cols ...Col) *Table { return &Table{b: b, cols: cols} }
+
+// Method Row appends a row; cells are formatted as by %v, but JSONL keeps
+// them as JSON values. Missing cells are empty, excess ones are dropped.
+func (t *Table) Row(a ...interface{}) *Table {
+	if t.b.Mode == JSONL { // numbers stay numbers
+		vals := make([]interface{}, len(t.cols))
+		for i := range vals {
+			vals[i] = ""
+			if i < len(a) {
+				vals[i] = a[i]
+			}
+		}
+		t.vals = append(t.vals, vals)
+		return t
+	}
+	row := make([]string, len(t.cols))
+	for i := 0; i < len(row) && i < len(a); i++ {
+		row[i] = fmt.Sprint(a[i])
+	}
+	if t.b.Mode == CSV || t.b.Mode == TSV {
+		t.csv(row)
+		return t
+	}
+	t.rows = append(t.rows, row)
+	return t
+}
+
+// Method Print writes the table to the Bld, then forgets its rows.
+func (t *Table) Print() {
+	defer func() { t.rows, t.vals, t.cw = t.rows[:0], t.vals[:0], nil }()
+	switch t.b.Mode {
+	case CSV, TSV:
+		if t.cw == nil { // no rows, just a header
+			t.csv(nil)
+		}
+	case JSONL:
+		for _, row := range t.vals {
+			fs := make([]interface{}, 0, 2*len(row))
+			for i, c := range row {
+				fs = append(fs, t.cols[i].Name, c)
+			}
+			t.b.record("info", "", fs)
+		}
+	case Markdown:
+		t.markdown()
+	default:
+		t.text()
+	}
+}
+
+// csv writes a header, if not written yet, then the row
+func (t *Table) csv(row []string) {
+	if t.cw == nil {
+		if t.b.sbu == nil {
+			t.b.autonew()
+		}
+		t.cw = csv.NewWriter(putw{t.b})
+		if t.b.Mode == TSV {
+			t.cw.Comma = '\t'
+		} else {
+			t.cw.UseCRLF = true
+		}
+		head := make([]string, len(t.cols))
+		for i, c := range t.cols {
+			head[i] = c.Name
+		}
+		t.cw.Write(head)
+	}
+	if row != nil {
+		t.cw.Write(row)
+	}
+	t.cw.Flush()
+}
+
+func (t *Table) text() {
+	ws := make([]int, len(t.cols))
+	for i, c := range t.cols {
+		ws[i] = dwidth(c.Name)
+		for _, row := range t.rows {
+			if c.Width > 0 {
+				row[i] = truncate(row[i], c.Width)
+			}
+			if w := dwidth(row[i]); w > ws[i] {
+				ws[i] = w
+			}
+		}
+	}
+	line := func(cells []string, right func(int) bool) {
+		var o strings.Builder
+		for i, c := range cells {
+			if i > 0 {
+				o.WriteString("  ")
+			}
+			fill := strings.Repeat(" ", ws[i]-dwidth(c))
+			if right(i) {
+				o.WriteString(fill + c)
+			} else {
+				o.WriteString(c + fill)
+			}
+		}
+		t.b.Printf("%s\n", strings.TrimRight(o.String(), " "))
+	}
+	head, rule := make([]string, len(t.cols)), make([]string, len(t.cols))
+	for i, c := range t.cols {
+		head[i], rule[i] = c.Name, strings.Repeat("-", ws[i])
+	}
+	left := func(int) bool { return false }
+	line(head, left)
+	line(rule, left)
+	for _, row := range t.rows {
+		line(row, func(i int) bool { return t.cols[i].Right })
+	}
+}
+
+func (t *Table) markdown() {
+	cell := func(s string) string {
+		return strings.Replace(strings.Replace(s, "|", `\|`, -1), "\n", "<br>", -1)
+	}
+	var head, rule strings.Builder
+	for _, c := range t.cols {
+		head.WriteString("| " + cell(c.Name) + " ")
+		if c.Right {
+			rule.WriteString("| ---: ")
+		} else {
+			rule.WriteString("| --- ")
+		}
+	}
+	t.b.mdgap()
+	t.b.Printf("%s|\n", head.String())
+	t.b.Printf("%s|\n", rule.String())
+	for _, row := range t.rows {
+		var o strings.Builder
+		for _, c := range row {
+			o.WriteString("| " + cell(c) + " ")
+		}
+		t.b.Printf("%s|\n", o.String())
+	}
+}
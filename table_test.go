@@ -0,0 +1,41 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import "testing"
+
+func TestTable(t *testing.T) {
+	defer fakeClock(0)()
+	bu := New(1)
+	cols := []Col{{Name: "file", Width: 8}, {Name: "size", Right: true}, {Name: "note"}}
+	fill := func(tb *Table) *Table {
+		return tb.Row("a.txt", 12, `say "hi", | bye`).Row("longer_name.txt", 3456).Row("c", 7, "x", "dropped")
+	}
+	for _, c := range []struct {
+		mode Mode
+		exp  string
+	}{
+		{Text, "file      size  note\n--------  ----  ---------------\na.txt       12  say \"hi\", | bye\nlonger_…  3456\nc            7  x\n"},
+		{Markdown, "| file | size | note |\n| --- | ---: | --- |\n| a.txt | 12 | say \"hi\", \\| bye |\n| longer_name.txt | 3456 |  |\n| c | 7 | x |\n"},
+		{CSV, "file,size,note\r\na.txt,12,\"say \"\"hi\"\", | bye\"\r\nlonger_name.txt,3456,\r\nc,7,x\r\n"},
+		{TSV, "file\tsize\tnote\na.txt\t12\t\"say \"\"hi\"\", | bye\"\nlonger_name.txt\t3456\t\nc\t7\tx\n"},
+		{JSONL, `{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"","file":"a.txt","size":12,"note":"say \"hi\", | bye"}` + "\n" +
+			`{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"","file":"longer_name.txt","size":3456,"note":""}` + "\n" +
+			`{"time":"2021-11-05T12:00:00.000Z","level":"info","msg":"","file":"c","size":7,"note":"x"}` + "\n"},
+	} {
+		bu.Clear()
+		bu.Mode = c.mode
+		fill(bu.Table(cols...)).Print()
+		if bu.String() != c.exp {
+			t.Logf("%v: expected:\n%q\nbut got:\n%q", c.mode, c.exp, bu.String())
+			t.Fail()
+		}
+	}
+	bu.Clear()
+	bu.Mode = CSV
+	bu.Table(cols...).Print()
+	if exp := "file,size,note\r\n"; bu.String() != exp {
+		t.Logf("Expected header only %q, but got %q", exp, bu.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,39 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Method Trace prints "→ pkg.Func(args)" line for the function it is called
+// from, then indents all b output until the returned func is called. That
+// func prints "← pkg.Func 12ms" and restores indentation. Args, if given,
+// are printed as passed in - with strings quoted. Eg. usage:
+//
+//	func walk(n *Node, depth int) {
+//		defer pb.Trace(n.Name, depth)()
+//		...
+func (b *Bld) Trace(args ...interface{}) func() {
+	name := shortfunc(callsite().Function)
+	as := make([]string, len(args))
+	for i, a := range args {
+		if s, ok := a.(string); ok {
+			as[i] = fmt.Sprintf("%q", s)
+		} else {
+			as[i] = fmt.Sprint(a)
+		}
+	}
+	b.Printf("→ %s(%s)\n", name, strings.Join(as, ", "))
+	b.ind = append(b.ind[:len(b.ind):len(b.ind)], "  "...)
+	b.haspfx = true
+	t0 := now()
+	return func() {
+		if len(b.ind) >= 2 {
+			b.ind = b.ind[:len(b.ind)-2]
+		}
+		b.haspfx = len(b.pfx) > 0 || b.ptpl != nil || len(b.ind) > 0
+		b.Printf("← %s %s\n", name, hdur(now().Sub(t0)))
+	}
+}
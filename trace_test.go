@@ -0,0 +1,35 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"testing"
+	"time"
+)
+
+func traced(bu *Bld, n int) int {
+	defer bu.Trace(n, "x")()
+	if n < 1 {
+		bu.Printf("bottom\n")
+		return 0
+	}
+	return traced(bu, n-1) + 1
+}
+
+func TestTrace(t *testing.T) {
+	defer fakeClock(time.Millisecond)()
+	bu := New(1)
+	bu.Prefix("> ")
+	traced(&bu, 1)
+	bu.Printf("after\n")
+	exp := "> → cout.traced(1, \"x\")\n" +
+		">   → cout.traced(0, \"x\")\n" +
+		">     bottom\n" +
+		">   ← cout.traced 1ms\n" +
+		"> ← cout.traced 3ms\n" +
+		"> after\n"
+	if bu.String() != exp {
+		t.Logf("Expected:\n%s\nbut got:\n%s", exp, bu.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,115 @@
+// (c) 2021 Ohir Ripe. MIT license.
+
+package cout
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dwidth returns number of terminal cells s takes. ANSI escape sequences
+// take none, nor do combining marks; East Asian wide characters take two.
+func dwidth(s string) (w int) {
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b { // ESC [ params final
+			i = skipesc(s, i)
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		w += rwidth(r)
+	}
+	return w
+}
+
+// skipesc returns index past the escape sequence starting at s[i]
+func skipesc(s string, i int) int {
+	i++
+	if i >= len(s) || s[i] != '[' {
+		return i
+	}
+	for i++; i < len(s); i++ {
+		if c := s[i]; c >= 0x40 && c <= 0x7e {
+			return i + 1
+		}
+	}
+	return i
+}
+
+// rwidth returns number of terminal cells r takes
+func rwidth(r rune) int {
+	switch {
+	case r < 0x20, r == 0x7f:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+	for _, rg := range wide {
+		if r >= rg[0] && r <= rg[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// East Asian Wide and Fullwidth, emoji
+var wide = [][2]rune{
+	{0x1100, 0x115f}, {0x2e80, 0x303e}, {0x3041, 0x33ff},
+	{0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe30, 0xfe4f},
+	{0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x1f300, 0x1f64f},
+	{0x1f900, 0x1f9ff}, {0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
+
+// wrap splits s into lines at most w cells wide, breaking at spaces.
+// Words longer than w are left whole. Newlines in s are kept.
+func wrap(s string, w int) (lines []string) {
+	for _, para := range strings.Split(s, "\n") {
+		if w < 1 || dwidth(para) <= w {
+			lines = append(lines, para)
+			continue
+		}
+		ln, lw := "", 0
+		for _, word := range strings.Fields(para) {
+			ww := dwidth(word)
+			switch {
+			case lw == 0:
+				ln, lw = word, ww
+			case lw+1+ww <= w:
+				ln, lw = ln+" "+word, lw+1+ww
+			default:
+				lines = append(lines, ln)
+				ln, lw = word, ww
+			}
+		}
+		lines = append(lines, ln)
+	}
+	return lines
+}
+
+// truncate cuts s to be at most w cells wide, ending it with an ellipsis.
+func truncate(s string, w int) string {
+	if dwidth(s) <= w {
+		return s
+	}
+	if w < 1 {
+		return ""
+	}
+	cw := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i = skipesc(s, i)
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if cw+rwidth(r) > w-1 {
+			return s[:i] + "…"
+		}
+		cw += rwidth(r)
+		i += n
+	}
+	return s
+}